@@ -1,9 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
-	"sync"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
 
 	"github.com/acifani/vita/lib/game"
 )
@@ -14,12 +18,39 @@ var (
 	generations = flag.Int("gens", 3, "how many generations to run the universe")
 	population  = flag.Int("pop", 45, "initial population percent of the universe")
 	number      = flag.Int("n", 1, "number of universes to run in parallel")
-	rules       = flag.String("rules", "conway", "rules to use for the universe")
+	rules       = flag.String("rules", "conway", "rules to use for the universe, by name or rulestring")
+	wrap        = flag.Bool("wrap", false, "wrap the edges of the universe")
+	csv         = flag.Bool("csv", false, "print population statistics as CSV instead of the universe")
+	census      = flag.Bool("census", false, "print the objects left in the universe at the end of the run")
+	objects     = flag.Bool("objects", false, "print each object left in the universe at the end of the run as RLE")
+	soups       = flag.Int("soups", 0, "number of seeded soups to run until they stabilize, instead of a single universe")
+	seed        = flag.Int64("seed", 1, "seed of the random cells of the universe, or of the first soup")
+	symmetry    = flag.String("symmetry", "C1", "symmetry of the random cells, C1, C2, C4 or D8")
+	noise       = flag.String("noise", "uniform", "distribution of the random cells, uniform or perlin")
+	format      = flag.String("format", "csv", "format of the soup results, csv or json")
+	cycle       = flag.Int("cycle", 0, "stop once the universe repeats itself within this many generations")
+	pngPath     = flag.String("png", "", "write an image of the universe at the end of the run to this PNG file")
+	gifPath     = flag.String("gif", "", "record the run to this animated GIF file instead of printing it")
+	svgPath     = flag.String("svg", "", "write a vector image of the universe at the end of the run to this SVG file")
+	cellSize    = flag.Int("cell", 4, "size of a cell in pixels in images")
+	grid        = flag.Bool("grid", false, "draw gridlines in images")
+	delay       = flag.Int("delay", 10, "time between the frames of animated GIFs in hundredths of a second")
 )
 
 func main() {
 	flag.Parse()
 
+	// "wrap" used to be its own set of rules.
+	if *rules == "wrap" {
+		*rules = "conway"
+		*wrap = true
+	}
+
+	if *soups > 0 {
+		runSoups()
+		return
+	}
+
 	if *number > 1 {
 		multi := createParallelUniverses()
 		connectParallelUniverses(multi)
@@ -33,57 +64,198 @@ func main() {
 
 func runSingleUniverse() {
 	universe := game.NewUniverse(uint32(*height), uint32(*width))
-	switch *rules {
-	case "dayandnight":
-		universe.Rules = game.DayAndNightRules
-	case "seeds":
-		universe.Rules = game.SeedsRules
-	case "wrap":
-		universe.Rules = game.ConwayRulesWrap
-	default:
-		// just use conway
+	universe.SetRule(lookupRule())
+	if *wrap {
+		universe.Boundary = game.BoundaryWrap
+	}
+
+	randomize(universe)
+
+	if *gifPath != "" {
+		writeFile(*gifPath, func(w io.Writer) error {
+			return universe.WriteGIF(w, *generations, renderOptions())
+		})
+	} else {
+		printRun(universe)
+	}
+
+	if *census {
+		printCensus(universe)
+	}
+
+	if *objects {
+		for _, c := range universe.Components() {
+			fmt.Printf("#C at row %d, column %d\n%s", c.Row, c.Column, c.Figure.RLE())
+		}
+	}
+
+	if *pngPath != "" {
+		writeFile(*pngPath, func(w io.Writer) error {
+			return universe.WritePNG(w, renderOptions())
+		})
+	}
+
+	if *svgPath != "" {
+		writeFile(*svgPath, func(w io.Writer) error {
+			_, err := io.WriteString(w, universe.SVG(renderOptions()))
+			return err
+		})
+	}
+}
+
+// randomize fills the universe with random cells, which are the same on
+// every run when a seed is given.
+func randomize(universe *game.Universe) {
+	opts := game.RandomOptions{Density: *population, Symmetry: lookupSymmetry()}
+	if err := opts.Noise.UnmarshalText([]byte(*noise)); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid noise %q: %v\n", *noise, err)
+		os.Exit(2)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			opts.Rand = rand.New(rand.NewSource(*seed))
+		}
+	})
+
+	if err := universe.RandomizeWith(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot randomize the universe: %v\n", err)
+		os.Exit(2)
+	}
+}
+
+func printRun(universe *game.Universe) {
+	if *csv {
+		fmt.Println("generation,population,births,deaths")
+	}
+	if *generations <= 0 {
+		return
+	}
+
+	runner := game.NewRunner(uint32(*generations))
+	if *cycle > 0 {
+		runner.Stop = append(runner.Stop, game.StopWhenPeriodic(uint32(*cycle)))
+	}
+
+	// Every generation is printed before it is advanced, so the last one
+	// reached is not.
+	run := runner.Start(universe)
+	for !run.Done() {
+		if *csv {
+			stats := universe.Stats()
+			fmt.Printf("%d,%d,%d,%d\n", stats.Generation, stats.Population, stats.Births, stats.Deaths)
+		} else {
+			fmt.Println(universe)
+			fmt.Println()
+		}
+
+		run.Next()
+	}
+
+	if result := run.Result(); result.Reason == game.ReasonPeriodic {
+		fmt.Fprintf(os.Stderr, "Entered a cycle of period %d at generation %d\n", result.Cycle.Period, result.Cycle.Start)
+	}
+}
+
+func renderOptions() game.RenderOptions {
+	return game.RenderOptions{CellSize: *cellSize, Grid: *grid, Delay: *delay}
+}
+
+// writeFile creates the file at path and writes to it, exiting on errors.
+func writeFile(path string, write func(w io.Writer) error) {
+	f, err := os.Create(path)
+	if err == nil {
+		err = write(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
 	}
 
-	universe.Randomize(*population)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot write %s: %v\n", path, err)
+		os.Exit(1)
+	}
+}
 
-	for i := 0; i < *generations; i++ {
-		fmt.Println(universe)
-		fmt.Println()
+func printCensus(universe *game.Universe) {
+	counts := universe.Census()
+	codes := make([]string, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if counts[codes[i]] != counts[codes[j]] {
+			return counts[codes[i]] > counts[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+
+	for _, code := range codes {
+		if name, ok := game.ObjectName(code); ok {
+			fmt.Printf("%6d %s (%s)\n", counts[code], code, name)
+		} else {
+			fmt.Printf("%6d %s\n", counts[code], code)
+		}
+	}
+}
+
+func runSoups() {
+	opts := game.SoupOptions{
+		Height:         uint32(*height),
+		Width:          uint32(*width),
+		Density:        *population,
+		Symmetry:       lookupSymmetry(),
+		Rule:           lookupRule(),
+		MaxGenerations: uint32(*generations),
+		MaxPeriod:      uint32(*cycle),
+	}
+	if *wrap {
+		opts.Boundary = game.BoundaryWrap
+	}
+
+	results, err := game.SweepSoups(*seed, *soups, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot run the soups: %v\n", err)
+		os.Exit(2)
+	}
 
-		universe.Tick()
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(results)
+	default:
+		fmt.Println("seed,lifespan,stabilized,period,final_population,peak_population,peak_generation")
+		for _, r := range results {
+			fmt.Printf("%d,%d,%t,%d,%d,%d,%d\n", r.Seed, r.Lifespan, r.Stabilized, r.Period,
+				r.FinalPopulation, r.PeakPopulation, r.PeakGeneration)
+		}
 	}
 }
 
 func runParallelUniverses(multi []*game.ParallelUniverse) {
-	for i := 0; i < *generations; i++ {
+	if *generations <= 0 {
+		return
+	}
+
+	run := game.NewRunner(uint32(*generations)).Start(game.ParallelGroup(multi))
+	for !run.Done() {
 		for i, u := range multi {
 			fmt.Println("Universe", i)
 			fmt.Println(u)
 			fmt.Println()
 		}
 
-		var wg sync.WaitGroup
-		for _, u := range multi[:len(multi)] {
-			callMultiTick(&wg, u)
-		}
-		wg.Wait()
+		run.Next()
 	}
 }
 
 func createParallelUniverses() []*game.ParallelUniverse {
+	rule := lookupRule()
 	multi := []*game.ParallelUniverse{}
 	for row := 0; row < *number; row++ {
 		for col := 0; col < *number; col++ {
 			u := game.NewParallelUniverse(uint32(*height), uint32(*width))
-			switch *rules {
-			case "dayandnight":
-				u.Rules = game.DayAndNightRules
-			case "seeds":
-				u.Rules = game.SeedsRules
-			default:
-				// just use conway
-			}
-
+			u.SetRule(rule)
 			u.Randomize(*population)
 			multi = append(multi, u)
 		}
@@ -92,6 +264,26 @@ func createParallelUniverses() []*game.ParallelUniverse {
 	return multi
 }
 
+func lookupRule() *game.Rule {
+	rule, err := game.LookupRule(*rules)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid rules %q: %v\n", *rules, err)
+		os.Exit(2)
+	}
+
+	return rule
+}
+
+func lookupSymmetry() game.Symmetry {
+	var s game.Symmetry
+	if err := s.UnmarshalText([]byte(*symmetry)); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid symmetry %q: %v\n", *symmetry, err)
+		os.Exit(2)
+	}
+
+	return s
+}
+
 func connectParallelUniverses(multi []*game.ParallelUniverse) {
 	i := 0
 	for row := 0; row < *number; row++ {
@@ -152,11 +344,3 @@ func connectParallelUniverses(multi []*game.ParallelUniverse) {
 		}
 	}
 }
-
-func callMultiTick(wg *sync.WaitGroup, u *game.ParallelUniverse) {
-	wg.Add(1)
-	go func() {
-		u.MultiTick()
-		wg.Done()
-	}()
-}
@@ -0,0 +1,70 @@
+package game
+
+// Activity counts, for every cell of a universe, how many times it was born,
+// died or stayed alive since tracking started. The counts are indexed like
+// the cells, see GetIndex.
+type Activity struct {
+	// Generations is the number of ticks that have been tracked.
+	Generations uint32
+	Born        []uint32
+	Died        []uint32
+	Alive       []uint32
+}
+
+func newActivity(size int) *Activity {
+	return &Activity{
+		Born:  make([]uint32, size),
+		Died:  make([]uint32, size),
+		Alive: make([]uint32, size),
+	}
+}
+
+func (a *Activity) add(cells, newCells []uint8) {
+	a.Generations++
+	for i, cell := range newCells {
+		switch {
+		case cell != Dead && cells[i] == Dead:
+			a.Born[i]++
+		case cell == Dead && cells[i] != Dead:
+			a.Died[i]++
+		}
+		if cell != Dead {
+			a.Alive[i]++
+		}
+	}
+}
+
+// Heat returns how often each cell changed state, from 0 for cells that
+// never changed to 1 for the most active ones.
+func (a *Activity) Heat() []float64 {
+	var hottest uint32
+	for i := range a.Born {
+		hottest = max(hottest, a.Born[i]+a.Died[i])
+	}
+
+	heat := make([]float64, len(a.Born))
+	if hottest == 0 {
+		return heat
+	}
+	for i := range heat {
+		heat[i] = float64(a.Born[i]+a.Died[i]) / float64(hottest)
+	}
+
+	return heat
+}
+
+// TrackActivity starts counting births, deaths and live cells on every
+// Tick from zero, or stops counting when enable is false.
+func (u *Universe) TrackActivity(enable bool) {
+	if !enable {
+		u.activity = nil
+		return
+	}
+
+	u.activity = newActivity(len(u.cells))
+}
+
+// Activity returns the counts enabled by TrackActivity, or nil.
+func (u *Universe) Activity() *Activity {
+	return u.activity
+}
@@ -0,0 +1,41 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestActivity(t *testing.T) {
+	u := NewUniverse(8, 8)
+	u.SetRectangle(3, 2, [][]uint8{{Alive, Alive, Alive}})
+	u.TrackActivity(true)
+
+	u.Tick()
+	u.Tick()
+
+	a := u.Activity()
+	if a.Generations != 2 {
+		t.Errorf("Expected 2 generations, got %d", a.Generations)
+	}
+
+	// The center of the blinker is always alive, its ends blink.
+	center, end, tip := u.GetIndex(3, 3), u.GetIndex(3, 2), u.GetIndex(2, 3)
+	if a.Alive[center] != 2 || a.Born[center] != 0 || a.Died[center] != 0 {
+		t.Errorf("Expected center to stay alive, got %d, %d, %d", a.Alive[center], a.Born[center], a.Died[center])
+	}
+	if a.Alive[end] != 1 || a.Born[end] != 1 || a.Died[end] != 1 {
+		t.Errorf("Expected end to die and be born, got %d, %d, %d", a.Alive[end], a.Born[end], a.Died[end])
+	}
+	if a.Alive[tip] != 1 || a.Born[tip] != 1 || a.Died[tip] != 1 {
+		t.Errorf("Expected tip to be born and die, got %d, %d, %d", a.Alive[tip], a.Born[tip], a.Died[tip])
+	}
+
+	heat := a.Heat()
+	if heat[center] != 0 || heat[end] != 1 || heat[u.GetIndex(0, 0)] != 0 {
+		t.Errorf("Expected heat to be 0, 1 and 0, got %f, %f, %f", heat[center], heat[end], heat[u.GetIndex(0, 0)])
+	}
+
+	u.TrackActivity(false)
+	if u.Activity() != nil {
+		t.Errorf("Expected activity to be nil")
+	}
+}
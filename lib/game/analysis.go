@@ -0,0 +1,181 @@
+package game
+
+import (
+	"strconv"
+)
+
+// Kind tells how a pattern behaves when it evolves in isolation.
+type Kind uint8
+
+const (
+	// Unknown patterns do not repeat within the analyzed generations.
+	Unknown Kind = iota
+	// Extinct patterns die out completely.
+	Extinct
+	// StillLife patterns do not change from one generation to the next.
+	StillLife
+	// Oscillator patterns repeat in the same position after their period.
+	Oscillator
+	// Spaceship patterns repeat in a different position after their period.
+	Spaceship
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Extinct:
+		return "extinct"
+	case StillLife:
+		return "still life"
+	case Oscillator:
+		return "oscillator"
+	case Spaceship:
+		return "spaceship"
+	default:
+		return "unknown"
+	}
+}
+
+// Rect is a rectangle of cells. Row and Column can be negative when it is
+// relative to a pattern that moved up or to the left.
+type Rect struct {
+	Row, Column   int
+	Height, Width int
+}
+
+func (r Rect) union(other Rect) Rect {
+	if r.Height == 0 || r.Width == 0 {
+		return other
+	}
+	if other.Height == 0 || other.Width == 0 {
+		return r
+	}
+
+	top, left := min(r.Row, other.Row), min(r.Column, other.Column)
+	bottom := max(r.Row+r.Height, other.Row+other.Height)
+	right := max(r.Column+r.Width, other.Column+other.Width)
+	return Rect{Row: top, Column: left, Height: bottom - top, Width: right - left}
+}
+
+// Analysis describes how a pattern behaves when it evolves in isolation.
+type Analysis struct {
+	Kind Kind
+	// Code is the apgcode of the pattern, see Classify.
+	Code string
+	// Start is the generation at which the pattern enters its cycle.
+	Start int
+	// Period is the number of generations of the cycle.
+	Period int
+	// Rows and Columns are the displacement of a spaceship over a period,
+	// positive towards the bottom and the right.
+	Rows, Columns int
+	// Bounds is the bounding box of all the phases of the cycle, relative
+	// to the top-left corner of the analyzed pattern.
+	Bounds Rect
+}
+
+// Speed returns the speed of a spaceship in the usual notation, e.g.
+// "c/4 diagonal" for a glider or "c/2 orthogonal" for a lightweight
+// spaceship, and an empty string for any other kind of pattern.
+// See https://conwaylife.com/wiki/Speed for more information.
+func (a Analysis) Speed() string {
+	if a.Kind != Spaceship {
+		return ""
+	}
+
+	rows, columns := abs(a.Rows), abs(a.Columns)
+	switch {
+	case rows == 0 || columns == 0:
+		return fraction(rows+columns, a.Period) + " orthogonal"
+	case rows == columns:
+		return fraction(rows, a.Period) + " diagonal"
+	default:
+		return "(" + strconv.Itoa(max(rows, columns)) + "," + strconv.Itoa(min(rows, columns)) + ")c/" +
+			strconv.Itoa(a.Period) + " oblique"
+	}
+}
+
+// fraction formats n/d as a multiple of c in lowest terms, such as "2c/5".
+func fraction(n, d int) string {
+	g := gcd(n, d)
+	n, d = n/g, d/g
+
+	s := "c"
+	if n != 1 {
+		s = strconv.Itoa(n) + s
+	}
+	if d != 1 {
+		s += "/" + strconv.Itoa(d)
+	}
+
+	return s
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// Analyze evolves the figure in isolation, for at most the given number of
+// generations, using the rule or B3/S23 if rule is nil, and reports whether
+// it dies, is a still life, an oscillator or a spaceship.
+func Analyze(f *Figure, rule *Rule, generations int) Analysis {
+	if rule == nil {
+		rule = conwayRule
+	}
+
+	values, row, column := trimValues(f.values)
+	cycle, start, rows, columns := evolve(values, rule, generations)
+
+	a := Analysis{Start: start, Period: len(cycle), Rows: rows, Columns: columns}
+	switch {
+	case cycle == nil:
+		a.Kind = Unknown
+	case len(cycle[0].values) == 0:
+		a.Kind = Extinct
+	case rows != 0 || columns != 0:
+		a.Kind = Spaceship
+	case len(cycle) > 1:
+		a.Kind = Oscillator
+	default:
+		a.Kind = StillLife
+	}
+	a.Code = apgcode(cycle, a.Kind)
+
+	for _, p := range cycle {
+		bounds := NewFigure(p.values)
+		a.Bounds = a.Bounds.union(Rect{
+			Row:    row + p.row,
+			Column: column + p.column,
+			Height: int(bounds.Height()),
+			Width:  int(bounds.Width()),
+		})
+	}
+
+	return a
+}
+
+// AnalyzeRegion analyzes the cells of a rectangle of the universe, as if
+// there were nothing around them, using the compiled rule of the universe.
+// The bounds of the analysis are relative to the top-left corner of the
+// rectangle.
+func (u *Universe) AnalyzeRegion(row, column, height, width uint32, generations int) Analysis {
+	values := make([][]uint8, 0, height)
+	for r := row; r < row+height && r < u.height && column < u.width; r++ {
+		start := u.GetIndex(r, column)
+		end := u.GetIndex(r, min(column+width, u.width))
+		values = append(values, append([]uint8(nil), u.cells[start:end]...))
+	}
+
+	return Analyze(NewFigure(values), u.rule, generations)
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	t.Run("Glider", func(t *testing.T) {
+		a := Analyze(Glider(), nil, 100)
+
+		if a.Kind != Spaceship || a.Period != 4 {
+			t.Errorf("Expected a spaceship of period 4, got %v of period %d", a.Kind, a.Period)
+		}
+		if a.Rows != 1 || a.Columns != 1 {
+			t.Errorf("Expected a displacement of (1, 1), got (%d, %d)", a.Rows, a.Columns)
+		}
+		if a.Speed() != "c/4 diagonal" {
+			t.Errorf("Expected speed to be c/4 diagonal, got %q", a.Speed())
+		}
+		if a.Code != "xq4_153" {
+			t.Errorf("Expected code to be xq4_153, got %q", a.Code)
+		}
+		expected := Rect{Row: 0, Column: 0, Height: 4, Width: 4}
+		if a.Bounds != expected {
+			t.Errorf("Expected bounds to be %+v, got %+v", expected, a.Bounds)
+		}
+	})
+
+	t.Run("Lightweight spaceship", func(t *testing.T) {
+		lwss := NewFigure([][]uint8{
+			{Dead, Alive, Dead, Dead, Alive},
+			{Alive, Dead, Dead, Dead, Dead},
+			{Alive, Dead, Dead, Dead, Alive},
+			{Alive, Alive, Alive, Alive, Dead},
+		})
+		a := Analyze(lwss, nil, 100)
+
+		if a.Speed() != "c/2 orthogonal" {
+			t.Errorf("Expected speed to be c/2 orthogonal, got %q", a.Speed())
+		}
+		if a.Rows != 0 || a.Columns != -2 {
+			t.Errorf("Expected a displacement of (0, -2), got (%d, %d)", a.Rows, a.Columns)
+		}
+	})
+
+	t.Run("Oscillator", func(t *testing.T) {
+		a := Analyze(NewFigure([][]uint8{{Alive, Alive, Alive}}), nil, 100)
+
+		if a.Kind != Oscillator || a.Period != 2 || a.Speed() != "" {
+			t.Errorf("Expected an oscillator of period 2, got %v of period %d", a.Kind, a.Period)
+		}
+		expected := Rect{Row: -1, Column: 0, Height: 3, Width: 3}
+		if a.Bounds != expected {
+			t.Errorf("Expected bounds to be %+v, got %+v", expected, a.Bounds)
+		}
+	})
+
+	t.Run("Still life after a transient", func(t *testing.T) {
+		// A pre-block becomes a block after one generation.
+		a := Analyze(NewFigure([][]uint8{{Alive, Alive}, {Alive, Dead}}), nil, 100)
+
+		if a.Kind != StillLife || a.Start != 1 || a.Code != "xs4_33" {
+			t.Errorf("Expected a block from generation 1, got %+v", a)
+		}
+	})
+
+	t.Run("Extinct", func(t *testing.T) {
+		a := Analyze(NewFigure([][]uint8{{Alive, Alive}}), nil, 100)
+
+		if a.Kind != Extinct || a.Start != 1 {
+			t.Errorf("Expected to die at generation 1, got %+v", a)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		a := Analyze(Pulsar(), nil, 2)
+
+		if a.Kind != Unknown || a.Code != UnknownObject {
+			t.Errorf("Expected an unknown pattern, got %+v", a)
+		}
+	})
+
+	t.Run("AnalyzeRegion", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.SetRectangle(4, 4, Glider().Values())
+		u.SetRectangle(12, 12, Beehive().Values())
+
+		a := u.AnalyzeRegion(3, 3, 5, 5, 100)
+		if a.Kind != Spaceship || a.Bounds.Row != 1 || a.Bounds.Column != 1 {
+			t.Errorf("Expected a spaceship at (1, 1), got %+v", a)
+		}
+	})
+}
+
+func TestSpeed(t *testing.T) {
+	for expected, a := range map[string]Analysis{
+		"c orthogonal":     {Kind: Spaceship, Period: 1, Rows: 1},
+		"2c/5 orthogonal":  {Kind: Spaceship, Period: 5, Columns: 2},
+		"c/10 orthogonal":  {Kind: Spaceship, Period: 10, Rows: -1},
+		"c/4 diagonal":     {Kind: Spaceship, Period: 4, Rows: -1, Columns: 1},
+		"(2,1)c/6 oblique": {Kind: Spaceship, Period: 6, Rows: 1, Columns: 2},
+	} {
+		if a.Speed() != expected {
+			t.Errorf("Expected speed to be %q, got %q", expected, a.Speed())
+		}
+	}
+}
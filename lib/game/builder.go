@@ -0,0 +1,112 @@
+package game
+
+// Placement describes how a figure is added to a Builder.
+type Placement struct {
+	// Row and Column are the position of the anchor of the figure.
+	Row, Column int
+	Figure      *Figure
+	// Flip mirrors the figure left to right, before it is rotated.
+	Flip bool
+	// Rotation is the number of clockwise quarter turns of the figure.
+	Rotation int
+	// Phase is the number of generations the figure is advanced by.
+	Phase int
+}
+
+// cells returns the cells of the placement, flipped, rotated and advanced,
+// and the position of the anchor relative to their top-left cell, which
+// advancing may move outside of them.
+func (p Placement) cells(r *Rule) (values [][]uint8, row, column int) {
+	f := p.Figure
+	if p.Flip {
+		f = f.FlipHorizontal()
+	}
+	f = f.Rotate(p.Rotation)
+	if p.Phase > 0 {
+		return f.advance(p.Phase, r)
+	}
+
+	return f.values, f.deltaX, f.deltaY
+}
+
+// Builder composes figures at relative positions into a single figure, for
+// example to set up collisions.
+type Builder struct {
+	rule  *Rule
+	cells map[[2]int]uint8
+	// overlaps lists the live cells set by more than one figure.
+	overlaps []Position
+	bounds   Rect
+}
+
+// NewBuilder returns an empty builder, where figures are advanced under the
+// given rule, or B3/S23 if rule is nil.
+func NewBuilder(rule *Rule) *Builder {
+	if rule == nil {
+		rule = conwayRule
+	}
+
+	return &Builder{rule: rule, cells: map[[2]int]uint8{}}
+}
+
+// Add places a figure. Live cells that are already set by another figure
+// are reported by Overlaps, and make Build fail.
+func (b *Builder) Add(p Placement) *Builder {
+	values, row, column := p.cells(b.rule)
+	f := &Figure{values: values}
+	top, left := p.Row-row, p.Column-column
+	b.bounds = b.bounds.union(Rect{Row: top, Column: left, Height: int(f.Height()), Width: int(f.Width())})
+
+	for r, row := range values {
+		for c, cell := range row {
+			if cell == Dead {
+				continue
+			}
+
+			position := [2]int{top + r, left + c}
+			if _, ok := b.cells[position]; ok {
+				b.overlaps = append(b.overlaps, Position{Row: int64(position[0]), Column: int64(position[1])})
+			}
+			b.cells[position] = cell
+		}
+	}
+
+	return b
+}
+
+// Overlaps returns the positions of the live cells set by more than one
+// figure.
+func (b *Builder) Overlaps() []Position {
+	return b.overlaps
+}
+
+// Bounds returns the smallest rectangle containing all the placed figures,
+// dead margins included.
+func (b *Builder) Bounds() Rect {
+	return b.bounds
+}
+
+// Build returns the composed figure, covering Bounds and anchored at the
+// origin of the placements if it lies within them. It fails if figures
+// overlap.
+func (b *Builder) Build() (*Figure, error) {
+	if len(b.overlaps) > 0 {
+		return nil, errOverlappingFigures
+	}
+
+	values := make([][]uint8, b.bounds.Height)
+	for r := range values {
+		values[r] = make([]uint8, b.bounds.Width)
+	}
+	for position, cell := range b.cells {
+		values[position[0]-b.bounds.Row][position[1]-b.bounds.Column] = cell
+	}
+
+	f := NewFigure(values)
+	if b.bounds.Row <= 0 && b.bounds.Column <= 0 &&
+		b.bounds.Row+b.bounds.Height > 0 && b.bounds.Column+b.bounds.Width > 0 {
+		f.deltaX, f.deltaY = -b.bounds.Row, -b.bounds.Column
+	}
+
+	return f, nil
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("Compose", func(t *testing.T) {
+		b := NewBuilder(nil).
+			Add(Placement{Figure: Glider()}).
+			Add(Placement{Column: 10, Figure: Glider(), Flip: true})
+
+		if b.Bounds() != (Rect{Row: -1, Column: -1, Height: 3, Width: 13}) {
+			t.Errorf("Expected bounds to cover both gliders, got %+v", b.Bounds())
+		}
+
+		f, err := b.Build()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := ".O.........O.\n..O.......O..\nOOO.......OOO\n"
+		if f.String() != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, f)
+		}
+		if f.DeltaX() != 1 || f.DeltaY() != 1 {
+			t.Errorf("Expected the anchor at the origin, got (%d, %d)", f.DeltaX(), f.DeltaY())
+		}
+	})
+
+	t.Run("Orientation and phase", func(t *testing.T) {
+		b := NewBuilder(nil).Add(Placement{Row: 5, Column: 5, Figure: Glider(), Rotation: 2, Phase: 4})
+		f, _ := b.Build()
+		u := NewUniverse(16, 16)
+		u.Stamp(b.Bounds().Row, b.Bounds().Column, f.Values(), BlendOr)
+
+		u2 := NewUniverse(16, 16)
+		u2.StampFigure(4, 4, Glider().Rotate(2), BlendOr)
+		if u.String() != u2.String() {
+			t.Errorf("Expected the glider one period further north-west:\n%s\ngot:\n%s", u2, u)
+		}
+	})
+
+	t.Run("Phase past a period", func(t *testing.T) {
+		for _, phase := range []int{5, 8, 12, 13} {
+			b := NewBuilder(nil).Add(Placement{Row: 8, Column: 8, Figure: Glider(), Phase: phase})
+			f, _ := b.Build()
+			u := NewUniverse(32, 32)
+			u.Stamp(b.Bounds().Row, b.Bounds().Column, f.Values(), BlendOr)
+
+			expected := NewUniverse(32, 32)
+			expected.StampFigure(8, 8, Glider(), BlendOr)
+			for i := 0; i < phase; i++ {
+				expected.Tick()
+			}
+			if !u.Equal(expected) {
+				t.Errorf("Expected the glider %d generations along:\n%s\ngot:\n%s", phase, expected, u)
+			}
+		}
+	})
+
+	t.Run("Overlaps", func(t *testing.T) {
+		b := NewBuilder(nil).
+			Add(Placement{Figure: Beehive()}).
+			Add(Placement{Column: 1, Figure: Beehive()})
+
+		if len(b.Overlaps()) != 2 {
+			t.Errorf("Expected two overlapping cells, got %v", b.Overlaps())
+		}
+		if f, err := b.Build(); err != errOverlappingFigures || f != nil {
+			t.Errorf("Expected error to be %v and no figure, got %v", errOverlappingFigures, err)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"embed"
+)
+
+// Categories of the patterns in the catalog.
+const (
+	CategoryStillLife  = "still life"
+	CategoryOscillator = "oscillator"
+	CategorySpaceship  = "spaceship"
+	CategoryGun        = "gun"
+	CategoryMethuselah = "methuselah"
+	CategoryPuffer     = "puffer"
+)
+
+//go:embed patterns/*.rle
+var patternFiles embed.FS
+
+// CatalogEntry describes a pattern of the catalog, stored as patterns/<Name>.rle.
+type CatalogEntry struct {
+	Name     string
+	Title    string
+	Category string
+	Rule     string
+	// Period is the number of generations after which the pattern repeats
+	// itself, or the period of the engine of guns and puffers.
+	Period int
+	// Speed is the speed of spaceships and puffers, as in Analysis.Speed.
+	Speed string
+	// Lifespan is the number of generations methuselahs take to stabilize.
+	Lifespan int
+}
+
+// Pattern loads the cells and metadata of the entry.
+func (e CatalogEntry) Pattern() (*Pattern, error) {
+	data, err := patternFiles.ReadFile("patterns/" + e.Name + ".rle")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRLE(string(data))
+}
+
+// catalog lists the patterns in patterns/, grouped by category.
+var catalog = []CatalogEntry{
+	{Name: "block", Title: "Block", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "beehive", Title: "Beehive", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "loaf", Title: "Loaf", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "boat", Title: "Boat", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "tub", Title: "Tub", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "ship", Title: "Ship", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "barge", Title: "Barge", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "long-boat", Title: "Long boat", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "pond", Title: "Pond", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "mango", Title: "Mango", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "snake", Title: "Snake", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "aircraft-carrier", Title: "Aircraft carrier", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+	{Name: "eater-1", Title: "Eater 1", Category: CategoryStillLife, Rule: "B3/S23", Period: 1},
+
+	{Name: "blinker", Title: "Blinker", Category: CategoryOscillator, Rule: "B3/S23", Period: 2},
+	{Name: "toad", Title: "Toad", Category: CategoryOscillator, Rule: "B3/S23", Period: 2},
+	{Name: "beacon", Title: "Beacon", Category: CategoryOscillator, Rule: "B3/S23", Period: 2},
+	{Name: "clock", Title: "Clock", Category: CategoryOscillator, Rule: "B3/S23", Period: 2},
+	{Name: "pulsar", Title: "Pulsar", Category: CategoryOscillator, Rule: "B3/S23", Period: 3},
+	{Name: "octagon-2", Title: "Octagon 2", Category: CategoryOscillator, Rule: "B3/S23", Period: 5},
+	{Name: "figure-eight", Title: "Figure eight", Category: CategoryOscillator, Rule: "B3/S23", Period: 8},
+	{Name: "koks-galaxy", Title: "Kok's galaxy", Category: CategoryOscillator, Rule: "B3/S23", Period: 8},
+	{Name: "tumbler", Title: "Tumbler", Category: CategoryOscillator, Rule: "B3/S23", Period: 14},
+	{Name: "pentadecathlon", Title: "Pentadecathlon", Category: CategoryOscillator, Rule: "B3/S23", Period: 15},
+	{Name: "queen-bee-shuttle", Title: "Queen bee shuttle", Category: CategoryOscillator, Rule: "B3/S23", Period: 30},
+
+	{Name: "glider", Title: "Glider", Category: CategorySpaceship, Rule: "B3/S23", Period: 4, Speed: "c/4 diagonal"},
+	{Name: "lwss", Title: "Lightweight spaceship", Category: CategorySpaceship, Rule: "B3/S23", Period: 4, Speed: "c/2 orthogonal"},
+	{Name: "mwss", Title: "Middleweight spaceship", Category: CategorySpaceship, Rule: "B3/S23", Period: 4, Speed: "c/2 orthogonal"},
+	{Name: "hwss", Title: "Heavyweight spaceship", Category: CategorySpaceship, Rule: "B3/S23", Period: 4, Speed: "c/2 orthogonal"},
+	{Name: "loafer", Title: "Loafer", Category: CategorySpaceship, Rule: "B3/S23", Period: 7, Speed: "c/7 orthogonal"},
+	{Name: "copperhead", Title: "Copperhead", Category: CategorySpaceship, Rule: "B3/S23", Period: 10, Speed: "c/10 orthogonal"},
+
+	{Name: "gosper-glider-gun", Title: "Gosper glider gun", Category: CategoryGun, Rule: "B3/S23", Period: 30},
+	{Name: "simkin-glider-gun", Title: "Simkin glider gun", Category: CategoryGun, Rule: "B3/S23", Period: 120},
+
+	{Name: "diehard", Title: "Diehard", Category: CategoryMethuselah, Rule: "B3/S23", Lifespan: 130},
+	{Name: "r-pentomino", Title: "R-pentomino", Category: CategoryMethuselah, Rule: "B3/S23", Lifespan: 1103},
+	{Name: "acorn", Title: "Acorn", Category: CategoryMethuselah, Rule: "B3/S23", Lifespan: 5206},
+
+	{Name: "puffer-train", Title: "Puffer train", Category: CategoryPuffer, Rule: "B3/S23", Period: 20, Speed: "c/2 orthogonal"},
+}
+
+// Catalog returns the entries of the pattern catalog in the given category,
+// or all of them if category is empty.
+func Catalog(category string) []CatalogEntry {
+	var entries []CatalogEntry
+	for _, e := range catalog {
+		if category == "" || e.Category == category {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries
+}
+
+// Categories returns the categories of the pattern catalog, in the order
+// in which Catalog lists them.
+func Categories() []string {
+	var categories []string
+	for i, e := range catalog {
+		if i == 0 || catalog[i-1].Category != e.Category {
+			categories = append(categories, e.Category)
+		}
+	}
+
+	return categories
+}
+
+// LoadPattern loads the pattern of the catalog with the given name.
+func LoadPattern(name string) (*Pattern, error) {
+	for _, e := range catalog {
+		if e.Name == name {
+			return e.Pattern()
+		}
+	}
+
+	return nil, errUnknownPattern
+}
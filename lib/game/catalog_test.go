@@ -0,0 +1,127 @@
+package game
+
+import (
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	t.Run("Files", func(t *testing.T) {
+		files, _ := fs.Glob(patternFiles, "patterns/*.rle")
+		if len(files) != len(Catalog("")) {
+			t.Errorf("Expected one entry per file, got %d files and %d entries", len(files), len(Catalog("")))
+		}
+
+		for _, e := range Catalog("") {
+			p, err := e.Pattern()
+			if err != nil {
+				t.Errorf("Expected %s to load, got %v", e.Name, err)
+				continue
+			}
+			if p.Name != e.Title || p.Rule != e.Rule {
+				t.Errorf("Expected %s to be %q in %s, got %q in %s", e.Name, e.Title, e.Rule, p.Name, p.Rule)
+			}
+		}
+	})
+
+	t.Run("Categories", func(t *testing.T) {
+		expected := []string{CategoryStillLife, CategoryOscillator, CategorySpaceship, CategoryGun, CategoryMethuselah, CategoryPuffer}
+		if strings.Join(Categories(), ",") != strings.Join(expected, ",") {
+			t.Errorf("Expected categories %q, got %q", expected, Categories())
+		}
+		for _, e := range Catalog(CategoryGun) {
+			if e.Category != CategoryGun {
+				t.Errorf("Expected only guns, got %s", e.Name)
+			}
+		}
+	})
+
+	t.Run("LoadPattern", func(t *testing.T) {
+		p, err := LoadPattern("glider")
+		if err != nil || p.String() != Glider().String() {
+			t.Errorf("Expected the glider, got %v:\n%s", err, p)
+		}
+		if _, err := LoadPattern("glider gun"); err != errUnknownPattern {
+			t.Errorf("Expected error to be %v, got %v", errUnknownPattern, err)
+		}
+	})
+}
+
+func TestCatalogMetadata(t *testing.T) {
+	for _, e := range Catalog("") {
+		p, _ := e.Pattern()
+		r, _ := LookupRule(e.Rule)
+
+		switch e.Category {
+		case CategoryStillLife, CategoryOscillator, CategorySpaceship:
+			a := Analyze(p.Figure, r, 2*e.Period)
+			if a.Period != e.Period || a.Speed() != e.Speed || (a.Kind == Spaceship) != (e.Category == CategorySpaceship) {
+				t.Errorf("Expected %s to be a %s of period %d at %q, got a %s of period %d at %q",
+					e.Name, e.Category, e.Period, e.Speed, a.Kind, a.Period, a.Speed())
+			}
+		case CategoryGun:
+			// Every period, the gun adds a glider to the universe.
+			s, _ := NewSparseUniverse(r)
+			s.SetFigure(0, 0, p.Figure)
+			populations := runSparse(s, 4*e.Period)
+			if populations[3*e.Period]-populations[2*e.Period] != 5 {
+				t.Errorf("Expected %s to emit a glider every %d generations, got %v", e.Name, e.Period,
+					populations[3*e.Period]-populations[2*e.Period])
+			}
+		case CategoryMethuselah:
+			// Once stable, only blinkers and gliders are left.
+			s, _ := NewSparseUniverse(r)
+			s.SetFigure(0, 0, p.Figure)
+			populations := runSparse(s, e.Lifespan+100)
+			for i := e.Lifespan; i < len(populations)-2; i++ {
+				if populations[i] != populations[i+2] {
+					t.Errorf("Expected %s to be stable after %d generations, changed at %d", e.Name, e.Lifespan, i)
+					break
+				}
+			}
+			if populations[e.Lifespan-1] == populations[e.Lifespan+1] {
+				t.Errorf("Expected %s to be unstable before %d generations", e.Name, e.Lifespan)
+			}
+		case CategoryPuffer:
+			// The engine at the front of the puffer repeats itself, moved forward.
+			s, _ := NewSparseUniverse(r)
+			s.SetFigure(0, 0, p.Figure)
+			runSparse(s, 20*e.Period)
+			before := sparseFront(s, 5)
+			row, _, _, _, _ := s.Bounds()
+			runSparse(s, e.Period)
+			row2, _, _, _, _ := s.Bounds()
+			if sparseFront(s, 5) != before || row-row2 != int64(e.Period/2) {
+				t.Errorf("Expected the front of %s to repeat every %d generations at %s", e.Name, e.Period, e.Speed)
+			}
+		}
+	}
+}
+
+// runSparse returns the populations of the universe over the given number
+// of generations, starting with the current one.
+func runSparse(s *SparseUniverse, generations int) []int {
+	populations := []int{s.Population()}
+	for i := 0; i < generations; i++ {
+		s.Tick()
+		populations = append(populations, s.Population())
+	}
+
+	return populations
+}
+
+// sparseFront returns the positions of the live cells in the first rows of
+// the universe, relative to its top row.
+func sparseFront(s *SparseUniverse, rows int64) string {
+	top, _, _, _, _ := s.Bounds()
+	builder := strings.Builder{}
+	for _, p := range s.Cells() {
+		if p.Row < top+rows {
+			builder.WriteString(strconv.FormatInt(p.Row-top, 10) + "," + strconv.FormatInt(p.Column, 10) + " ")
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"strings"
+)
+
+// ParseCells reads a pattern in the plaintext .cells format: rows of "O"
+// for live cells and "." for dead ones, preceded by comment lines starting
+// with "!". The "!Name:" and "!Author:" comments are read as metadata.
+// Rows shorter than the longest one are padded with dead cells.
+// See https://conwaylife.com/wiki/Plaintext for more information.
+func ParseCells(data string) (*Pattern, error) {
+	p := &Pattern{}
+	var values [][]uint8
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, "!") {
+			p.parseCellsComment(strings.TrimSpace(line[1:]))
+			continue
+		}
+
+		row := make([]uint8, 0, len(line))
+		for _, char := range line {
+			switch char {
+			case 'O', '*':
+				row = append(row, Alive)
+			case '.':
+				row = append(row, Dead)
+			default:
+				return nil, errInvalidCharacter
+			}
+		}
+		values = append(values, row)
+	}
+
+	// The final newline does not start another row.
+	for len(values) > 0 && len(values[len(values)-1]) == 0 {
+		values = values[:len(values)-1]
+	}
+
+	p.Figure = NewFigure(padValues(values))
+	return p, nil
+}
+
+func (p *Pattern) parseCellsComment(comment string) {
+	if name, ok := strings.CutPrefix(comment, "Name:"); ok {
+		p.Name = strings.TrimSpace(name)
+	} else if author, ok := strings.CutPrefix(comment, "Author:"); ok {
+		p.Author = strings.TrimSpace(author)
+	} else if rule, ok := strings.CutPrefix(comment, "Rule:"); ok {
+		p.Rule = strings.TrimSpace(rule)
+	} else {
+		p.Comments = append(p.Comments, comment)
+	}
+}
+
+// padValues extends every row with dead cells to the length of the longest.
+func padValues(values [][]uint8) [][]uint8 {
+	width := 0
+	for _, row := range values {
+		width = max(width, len(row))
+	}
+	for r := range values {
+		values[r] = append(values[r], make([]uint8, width-len(values[r]))...)
+	}
+
+	return values
+}
+
+// Cells returns the pattern in the plaintext .cells format, with its
+// metadata as "!" comments.
+func (p *Pattern) Cells() string {
+	builder := strings.Builder{}
+	if p.Name != "" {
+		builder.WriteString("!Name: " + p.Name + "\n")
+	}
+	if p.Author != "" {
+		builder.WriteString("!Author: " + p.Author + "\n")
+	}
+	if p.Rule != "" {
+		builder.WriteString("!Rule: " + p.Rule + "\n")
+	}
+	for _, comment := range p.Comments {
+		builder.WriteString("!" + comment + "\n")
+	}
+	builder.WriteString(p.Figure.String())
+
+	return builder.String()
+}
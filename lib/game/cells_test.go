@@ -0,0 +1,42 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestParseCells(t *testing.T) {
+	t.Run("Comments", func(t *testing.T) {
+		p, err := ParseCells("!Name: Glider\n!Author: Richard K. Guy\n!The smallest spaceship.\n.O\n..O\nOOO\n")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if p.Name != "Glider" || p.Author != "Richard K. Guy" {
+			t.Errorf("Expected metadata to be parsed, got %q, %q", p.Name, p.Author)
+		}
+		if len(p.Comments) != 1 || p.Comments[0] != "The smallest spaceship." {
+			t.Errorf("Expected one comment, got %q", p.Comments)
+		}
+		if p.String() != Glider().String() {
+			t.Errorf("Expected the glider, got:\n%s", p)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := ParseCells("!Name: x\nOXO\n"); err != errInvalidCharacter {
+			t.Errorf("Expected error to be %v, got %v", errInvalidCharacter, err)
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		p := &Pattern{Figure: Pulsar(), Name: "Pulsar", Comments: []string{"Period 3."}}
+		p2, err := ParseCells(p.Cells())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if p2.Cells() != p.Cells() {
+			t.Errorf("Expected:\n%s\ngot:\n%s", p.Cells(), p2.Cells())
+		}
+	})
+}
@@ -0,0 +1,314 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// objectDistance is how far apart live cells can be to belong to the
+	// same object. Cells two apart share a dead neighbor, so they interact.
+	objectDistance = 2
+
+	// classifyGenerations is how long an object is evolved in isolation
+	// looking for its period.
+	classifyGenerations = 512
+
+	// UnknownObject is the code of objects that do not repeat within
+	// the generations Classify evolves them for.
+	UnknownObject = "zz_UNKNOWN"
+)
+
+// object is a group of live cells of a universe, positioned by the top-left
+// corner of its bounding box.
+type object struct {
+	row, column int
+	values      [][]uint8
+}
+
+// objects separates the live cells of the universe into groups of cells
+// that are at most objectDistance apart.
+func (u *Universe) objects() []object {
+	visited := make([]bool, len(u.cells))
+
+	var objects []object
+	for start := range u.cells {
+		if !visited[start] && u.cells[start] != Dead {
+			objects = append(objects, u.object(start, visited))
+		}
+	}
+
+	return objects
+}
+
+// object returns the group of live cells that are at most objectDistance
+// apart from the live cell at the start index, marking them as visited.
+func (u *Universe) object(start int, visited []bool) object {
+	height, width := int(u.height), int(u.width)
+
+	visited[start] = true
+	queue := []int{start}
+	top, left, bottom, right := height, width, 0, 0
+	for i := 0; i < len(queue); i++ {
+		row, column := queue[i]/width, queue[i]%width
+		top, left = min(top, row), min(left, column)
+		bottom, right = max(bottom, row), max(right, column)
+
+		for r := max(row-objectDistance, 0); r <= min(row+objectDistance, height-1); r++ {
+			for c := max(column-objectDistance, 0); c <= min(column+objectDistance, width-1); c++ {
+				idx := r*width + c
+				if !visited[idx] && u.cells[idx] != Dead {
+					visited[idx] = true
+					queue = append(queue, idx)
+				}
+			}
+		}
+	}
+
+	values := make([][]uint8, bottom-top+1)
+	for r := range values {
+		values[r] = make([]uint8, right-left+1)
+	}
+	for _, idx := range queue {
+		values[idx/width-top][idx%width-left] = u.cells[idx]
+	}
+
+	return object{row: top, column: left, values: values}
+}
+
+// phase is the shape of an evolving object at one generation, positioned
+// relative to its shape at the first generation.
+type phase struct {
+	row, column int
+	values      [][]uint8
+}
+
+// step evolves the cells by one generation in an otherwise empty universe,
+// returning the trimmed result and its position relative to the cells.
+func step(values [][]uint8, r *Rule) ([][]uint8, int, int) {
+	f := NewFigure(values)
+	u := NewUniverse(f.Height()+2, f.Width()+2)
+	u.SetRule(r)
+	u.SetRectangle(1, 1, values)
+	u.Tick()
+
+	next, row, column := trimValues(u.rows())
+	return next, row - 1, column - 1
+}
+
+// evolve runs trimmed cells in isolation until one of their shapes repeats.
+// It returns the phases of the cycle, the generation at which the cycle
+// starts, and the displacement of the cells over one period. Dying cells
+// end in a cycle of a single empty phase. The cycle is nil if no shape
+// repeats within the given number of generations.
+func evolve(values [][]uint8, r *Rule, generations int) (cycle []phase, start, row, column int) {
+	current := phase{values: values}
+	phases := []phase{}
+	seen := map[string]int{}
+	for i := 0; i <= generations; i++ {
+		key := NewFigure(current.values).String()
+		if first, ok := seen[key]; ok {
+			origin := phases[first]
+			return phases[first:], first, current.row - origin.row, current.column - origin.column
+		}
+
+		seen[key] = len(phases)
+		phases = append(phases, current)
+
+		next, row, column := step(current.values, r)
+		current = phase{row: current.row + row, column: current.column + column, values: next}
+	}
+
+	return nil, 0, 0, 0
+}
+
+// Classify returns the apgcode of a single object evolving under the given
+// rule, or B3/S23 if rule is nil: "xs" followed by the population for still
+// lifes, "xp" or "xq" followed by the period for oscillators and spaceships,
+// then the extended Wechsler format of its canonical phase and orientation.
+// Objects that die return an empty string, and objects that do not settle
+// into a cycle return UnknownObject.
+// See https://conwaylife.com/wiki/Apgcode for more information.
+func Classify(f *Figure, rule *Rule) string {
+	return Analyze(f, rule, classifyGenerations).Code
+}
+
+// apgcode returns the code of a cycle of the given kind, see Classify.
+func apgcode(cycle []phase, kind Kind) string {
+	var prefix string
+	switch kind {
+	case Spaceship:
+		prefix = "xq" + strconv.Itoa(len(cycle))
+	case Oscillator:
+		prefix = "xp" + strconv.Itoa(len(cycle))
+	case StillLife:
+		prefix = "xs" + strconv.Itoa(population(cycle[0].values))
+	case Unknown:
+		return UnknownObject
+	default:
+		return ""
+	}
+
+	var best string
+	for _, p := range cycle {
+		v := p.values
+		for i := 0; i < 4; i++ {
+			for _, candidate := range []string{wechsler(v), wechsler(reflectValues(v))} {
+				if best == "" || len(candidate) < len(best) ||
+					(len(candidate) == len(best) && candidate < best) {
+					best = candidate
+				}
+			}
+			v = rotateValues(v)
+		}
+	}
+
+	return prefix + "_" + best
+}
+
+func population(values [][]uint8) int {
+	count := 0
+	for _, row := range values {
+		for _, cell := range row {
+			if cell != Dead {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+const wechslerDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// wechsler encodes the cells in the extended Wechsler format: strips of five
+// rows separated by "z", where each column of a strip is a base-32 digit and
+// runs of empty columns are shortened to "w", "x" or "y" and a count.
+func wechsler(values [][]uint8) string {
+	builder := strings.Builder{}
+	width := 0
+	for _, row := range values {
+		width = max(width, len(row))
+	}
+
+	for strip := 0; strip < len(values); strip += 5 {
+		if strip > 0 {
+			builder.WriteByte('z')
+		}
+
+		columns := make([]int, width)
+		for r := strip; r < min(strip+5, len(values)); r++ {
+			for c, cell := range values[r] {
+				if cell != Dead {
+					columns[c] |= 1 << (r - strip)
+				}
+			}
+		}
+		for len(columns) > 0 && columns[len(columns)-1] == 0 {
+			columns = columns[:len(columns)-1]
+		}
+
+		zeros := 0
+		for i := 0; i <= len(columns); i++ {
+			if i < len(columns) && columns[i] == 0 {
+				zeros++
+				continue
+			}
+
+			for zeros > 0 {
+				switch {
+				case zeros == 1:
+					builder.WriteByte('0')
+					zeros = 0
+				case zeros == 2:
+					builder.WriteByte('w')
+					zeros = 0
+				case zeros == 3:
+					builder.WriteByte('x')
+					zeros = 0
+				default:
+					n := min(zeros, 39)
+					builder.WriteByte('y')
+					builder.WriteByte(wechslerDigits[n-4])
+					zeros -= n
+				}
+			}
+			if i < len(columns) {
+				builder.WriteByte(wechslerDigits[columns[i]])
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// Census separates the live cells of the universe into objects and counts
+// them by their apgcode, see Classify. Objects are evolved with the compiled
+// rule of the universe, or B3/S23 if none is set. Cells that are less than
+// three cells apart belong to the same object, since they interact. Objects
+// that die in isolation are not counted, and objects that rely on the edges
+// of the universe to keep their shape may be classified differently.
+func (u *Universe) Census() map[string]int {
+	census := map[string]int{}
+	for _, o := range u.objects() {
+		if code := Classify(NewFigure(o.values), u.rule); code != "" {
+			census[code]++
+		}
+	}
+
+	return census
+}
+
+// ObjectName returns the common name of the object with the given apgcode.
+func ObjectName(code string) (string, bool) {
+	name, ok := objectNames[code]
+	return name, ok
+}
+
+// objectNames maps the apgcodes of common objects to their names.
+var objectNames = map[string]string{
+	// Still lifes
+	"xs4_33":    "block",
+	"xs4_252":   "tub",
+	"xs5_253":   "boat",
+	"xs6_356":   "ship",
+	"xs6_696":   "beehive",
+	"xs6_25a4":  "barge",
+	"xs6_39c":   "aircraft carrier",
+	"xs6_bd":    "snake",
+	"xs7_2596":  "loaf",
+	"xs7_25ac":  "long boat",
+	"xs7_178c":  "eater 1",
+	"xs8_6996":  "pond",
+	"xs8_69ic":  "mango",
+	"xs8_35ac":  "long ship",
+	"xs8_rr":    "bi-block",
+	"xs9_31ego": "beehive with tail",
+
+	// Oscillators
+	"xp2_7":                   "blinker",
+	"xp2_7e":                  "toad",
+	"xp2_318c":                "beacon",
+	"xp2_2a54":                "clock",
+	"xp15_4r4z4r4":            "pentadecathlon",
+	"xp8_4b23021eaz57840c4d2": "Kok's galaxy",
+
+	"xp3_co9nas0san9oczgoldlo0oldlogz1047210127401": "pulsar",
+
+	// Spaceships
+	"xq4_153":     "glider",
+	"xq4_6frc":    "lightweight spaceship",
+	"xq4_27dee6":  "middleweight spaceship",
+	"xq4_27deee6": "heavyweight spaceship",
+}
+
+// rows returns a copy of the cells of the universe, one slice per row.
+func (u *Universe) rows() [][]uint8 {
+	rows := make([][]uint8, u.height)
+	for r := range rows {
+		rows[r] = make([]uint8, u.width)
+		copy(rows[r], u.cells[uint32(r)*u.width:])
+	}
+
+	return rows
+}
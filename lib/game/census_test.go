@@ -0,0 +1,67 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	for name, test := range map[string]struct {
+		figure *Figure
+		code   string
+	}{
+		"Beehive": {Beehive(), "xs6_696"},
+		"Glider":  {Glider(), "xq4_153"},
+		"Block":   {NewFigure([][]uint8{{Alive, Alive}, {Alive, Alive}}), "xs4_33"},
+		"Blinker": {NewFigure([][]uint8{{Alive}, {Alive}, {Alive}}), "xp2_7"},
+		"Boat":    {NewFigure([][]uint8{{Alive, Alive, Dead}, {Alive, Dead, Alive}, {Dead, Alive, Dead}}), "xs5_253"},
+		"Dies":    {NewFigure([][]uint8{{Alive, Alive}}), ""},
+		"Empty":   {NewFigure([][]uint8{}), ""},
+	} {
+		if code := Classify(test.figure, nil); code != test.code {
+			t.Errorf("Expected %s to be %q, got %q", name, test.code, code)
+		}
+	}
+
+	if name, ok := ObjectName(Classify(Pulsar(), nil)); !ok || name != "pulsar" {
+		t.Errorf("Expected Pulsar to be named pulsar, got %q", name)
+	}
+}
+
+func TestWechsler(t *testing.T) {
+	values := [][]uint8{
+		{Alive, Dead, Dead, Dead, Dead, Dead, Alive},
+		{Dead, Dead, Dead, Dead, Dead, Dead, Dead},
+		{Dead, Dead, Dead, Dead, Dead, Dead, Dead},
+		{Dead, Dead, Dead, Dead, Dead, Dead, Dead},
+		{Dead, Dead, Dead, Dead, Dead, Dead, Dead},
+		{Dead, Dead, Alive, Alive, Dead, Dead, Dead},
+	}
+
+	if code := wechsler(values); code != "1y11zw11" {
+		t.Errorf("Expected %q, got %q", "1y11zw11", code)
+	}
+}
+
+func TestCensus(t *testing.T) {
+	t.Run("Objects", func(t *testing.T) {
+		u := NewUniverse(48, 32)
+		u.SetRectangle(2, 2, Beehive().Values())
+		u.SetRectangle(2, 20, Beehive().Values())
+		u.SetRectangle(20, 2, Glider().Values())
+		u.SetRectangle(20, 20, [][]uint8{{Alive, Alive, Alive}})
+		u.SetRectangle(28, 28, [][]uint8{{Alive}})
+		u.SetRectangle(32, 2, Pulsar().Values())
+		u.Tick()
+
+		census := u.Census()
+		expected := map[string]int{"xs6_696": 2, "xq4_153": 1, "xp2_7": 1, Classify(Pulsar(), nil): 1}
+		if len(census) != len(expected) {
+			t.Errorf("Expected census to be %v, got %v", expected, census)
+		}
+		for code, count := range expected {
+			if census[code] != count {
+				t.Errorf("Expected %d of %s, got %d", count, code, census[code])
+			}
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package game
+
+// Cycle describes a sequence of states that a universe keeps repeating.
+// See https://conwaylife.com/wiki/Oscillator for more information.
+type Cycle struct {
+	// Start is the generation at which the universe entered the cycle.
+	Start uint32
+	// Period is the number of generations after which the state repeats.
+	// A still life has a period of 1.
+	Period uint32
+}
+
+// cycleDetector remembers the hashes of the last maxPeriod states of a
+// universe, so that a repeated state can be found in constant time.
+type cycleDetector struct {
+	maxPeriod   uint32
+	generations []uint32
+	hashes      []uint64
+	seen        map[uint64]uint32
+	count       uint32
+
+	cycle Cycle
+	found bool
+}
+
+func newCycleDetector(maxPeriod uint32) *cycleDetector {
+	return &cycleDetector{
+		maxPeriod:   maxPeriod,
+		generations: make([]uint32, maxPeriod),
+		hashes:      make([]uint64, maxPeriod),
+		seen:        make(map[uint64]uint32, maxPeriod),
+	}
+}
+
+func (c *cycleDetector) observe(generation uint32, hash uint64) {
+	if previous, ok := c.seen[hash]; ok && generation > previous {
+		period := generation - previous
+		if !c.found || c.cycle.Period != period {
+			c.cycle = Cycle{Start: previous, Period: period}
+			c.found = true
+		}
+	} else {
+		c.found = false
+	}
+
+	slot := c.count % uint32(len(c.hashes))
+	if c.count >= uint32(len(c.hashes)) && c.seen[c.hashes[slot]] == c.generations[slot] {
+		delete(c.seen, c.hashes[slot])
+	}
+	c.hashes[slot] = hash
+	c.generations[slot] = generation
+	c.seen[hash] = generation
+	c.count++
+}
+
+func (c *cycleDetector) reset() {
+	clear(c.seen)
+	c.count = 0
+	c.found = false
+}
+
+// DetectCycles makes every Tick look for a state that already occurred in
+// the last maxPeriod generations, which is reported by Cycle. States are
+// compared by their Hash, so detection costs one pass over the cells per
+// generation. A maxPeriod of 0 disables detection.
+func (u *Universe) DetectCycles(maxPeriod uint32) {
+	if maxPeriod == 0 {
+		u.cycles = nil
+		return
+	}
+
+	u.cycles = newCycleDetector(maxPeriod)
+	u.cycles.observe(u.Generation, u.Hash())
+}
+
+// Cycle returns the cycle the universe is in, if DetectCycles is enabled and
+// the current state already occurred within its maximum period.
+func (u *Universe) Cycle() (Cycle, bool) {
+	if u.cycles == nil {
+		return Cycle{}, false
+	}
+
+	return u.cycles.cycle, u.cycles.found
+}
+
+// Hash returns the 64-bit FNV-1a hash of the cells of the universe.
+func (u *Universe) Hash() uint64 {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+
+	hash := uint64(offset)
+	for _, cell := range u.cells {
+		hash ^= uint64(cell)
+		hash *= prime
+	}
+
+	return hash
+}
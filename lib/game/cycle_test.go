@@ -0,0 +1,90 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestCycle(t *testing.T) {
+	t.Run("StillLife", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.DetectCycles(8)
+		u.SetRectangle(3, 3, Beehive().Values())
+
+		u.Tick()
+
+		cycle, ok := u.Cycle()
+		if !ok {
+			t.Fatalf("Expected a cycle to be detected")
+		}
+		if cycle.Period != 1 || cycle.Start != 0 {
+			t.Errorf("Expected period 1 from generation 0, got %+v", cycle)
+		}
+	})
+
+	t.Run("Oscillator", func(t *testing.T) {
+		u := NewUniverse(24, 24)
+		u.DetectCycles(8)
+		u.SetRectangle(5, 5, Pulsar().Values())
+
+		for i := 0; i < 2; i++ {
+			u.Tick()
+			if _, ok := u.Cycle(); ok {
+				t.Errorf("Expected no cycle at generation %d", u.Generation)
+			}
+		}
+
+		for i := 0; i < 5; i++ {
+			u.Tick()
+			cycle, ok := u.Cycle()
+			if !ok || cycle.Period != 3 || cycle.Start != 0 {
+				t.Errorf("Expected period 3 from generation 0, got %+v, %v", cycle, ok)
+			}
+		}
+	})
+
+	t.Run("Spaceship on a torus", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		u.Boundary = BoundaryWrap
+		r, _ := LookupRule("conway")
+		u.SetRule(r)
+		u.DetectCycles(32)
+		u.SetRectangle(0, 0, Glider().Values())
+
+		for i := 0; i < 31; i++ {
+			u.Tick()
+			if _, ok := u.Cycle(); ok {
+				t.Fatalf("Expected no cycle at generation %d", u.Generation)
+			}
+		}
+
+		u.Tick()
+		if cycle, ok := u.Cycle(); !ok || cycle.Period != 32 {
+			t.Errorf("Expected period 32, got %+v, %v", cycle, ok)
+		}
+	})
+
+	t.Run("Period longer than maxPeriod", func(t *testing.T) {
+		u := NewUniverse(24, 24)
+		u.DetectCycles(2)
+		u.SetRectangle(5, 5, Pulsar().Values())
+
+		for i := 0; i < 10; i++ {
+			u.Tick()
+			if _, ok := u.Cycle(); ok {
+				t.Errorf("Expected no cycle at generation %d", u.Generation)
+			}
+		}
+	})
+
+	t.Run("Edits restart detection", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.DetectCycles(8)
+		u.SetRectangle(3, 3, Beehive().Values())
+		u.Tick()
+
+		u.ToggleCellAt(10, 10)
+		if _, ok := u.Cycle(); ok {
+			t.Errorf("Expected no cycle after an edit")
+		}
+	})
+}
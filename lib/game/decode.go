@@ -0,0 +1,99 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/png"
+	"io"
+)
+
+// defaultThreshold is the luminance below which pixels are alive.
+const defaultThreshold = 128
+
+// ImportOptions configures how images are read into cells. The defaults
+// read back the images drawn with the default RenderOptions.
+type ImportOptions struct {
+	// Threshold is the luminance, from 0 to 255, below which pixels are
+	// alive, 128 if zero. Transparent pixels are always dead.
+	Threshold uint8
+	// Invert makes the pixels above the threshold alive instead.
+	Invert bool
+	// Palette maps colors to states, starting with Dead. When it is set,
+	// each pixel takes the state of the closest color in the palette and
+	// Threshold is ignored.
+	Palette color.Palette
+	// Scale is the width and height in pixels of the square drawn for each
+	// cell, 1 if zero. Each cell takes the value of the center of its square.
+	Scale int
+	// Grid skips the one pixel wide lines drawn around the cells.
+	Grid bool
+}
+
+// state returns the state of a pixel of the given color.
+func (opts ImportOptions) state(c color.Color) uint8 {
+	if len(opts.Palette) > 0 {
+		return uint8(min(opts.Palette.Index(c), 255))
+	}
+
+	if _, _, _, a := c.RGBA(); a == 0 {
+		return Dead
+	}
+
+	threshold := opts.Threshold
+	if threshold == 0 {
+		threshold = defaultThreshold
+	}
+	luminance := color.GrayModel.Convert(c).(color.Gray).Y
+	if (luminance < threshold) != opts.Invert {
+		return Alive
+	}
+
+	return Dead
+}
+
+// FigureFromImage reads the cells drawn in the image into a figure.
+func FigureFromImage(img image.Image, opts ImportOptions) *Figure {
+	scale := max(opts.Scale, 1)
+	border := 0
+	if opts.Grid {
+		border = 1
+	}
+
+	bounds := img.Bounds()
+	height := (bounds.Dy() - border) / (scale + border)
+	width := (bounds.Dx() - border) / (scale + border)
+	values := make([][]uint8, max(height, 0))
+	for r := range values {
+		values[r] = make([]uint8, max(width, 0))
+		y := bounds.Min.Y + border + r*(scale+border) + scale/2
+		for c := range values[r] {
+			x := bounds.Min.X + border + c*(scale+border) + scale/2
+			values[r][c] = opts.state(img.At(x, y))
+		}
+	}
+
+	return NewFigure(values)
+}
+
+// ReadImage decodes a PNG or GIF image and reads its cells into a figure.
+// Only the first frame of animated GIFs is read.
+func ReadImage(r io.Reader, opts ImportOptions) (*Figure, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return FigureFromImage(img, opts), nil
+}
+
+// ReadImage replaces the cells of the universe with the cells of a PNG or
+// GIF image, placed in the top-left corner.
+func (u *Universe) ReadImage(r io.Reader, opts ImportOptions) error {
+	f, err := ReadImage(r, opts)
+	if err != nil {
+		return err
+	}
+
+	return u.setPattern(NewPattern(f))
+}
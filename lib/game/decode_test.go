@@ -0,0 +1,72 @@
+package game
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestFigureFromImage(t *testing.T) {
+	t.Run("Threshold", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 3, 1))
+		img.Pix = []uint8{0x20, 0x90, 0xff}
+
+		if f := FigureFromImage(img, ImportOptions{}); f.String() != "O..\n" {
+			t.Errorf("Expected one live cell, got %q", f.String())
+		}
+		if f := FigureFromImage(img, ImportOptions{Threshold: 0xa0}); f.String() != "OO.\n" {
+			t.Errorf("Expected two live cells, got %q", f.String())
+		}
+		if f := FigureFromImage(img, ImportOptions{Invert: true}); f.String() != ".OO\n" {
+			t.Errorf("Expected inverted cells, got %q", f.String())
+		}
+	})
+
+	t.Run("Transparent", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+		img.Set(1, 0, color.Black)
+
+		if f := FigureFromImage(img, ImportOptions{}); f.String() != ".O\n" {
+			t.Errorf("Expected transparent pixels to be dead, got %q", f.String())
+		}
+	})
+
+	t.Run("Palette", func(t *testing.T) {
+		red, blue := color.RGBA{R: 0xff, A: 0xff}, color.RGBA{B: 0xff, A: 0xff}
+		img := image.NewRGBA(image.Rect(0, 0, 3, 1))
+		img.Set(0, 0, color.White)
+		img.Set(1, 0, color.RGBA{R: 0xf0, G: 0x10, A: 0xff})
+		img.Set(2, 0, blue)
+
+		f := FigureFromImage(img, ImportOptions{Palette: color.Palette{color.White, red, blue}})
+		for i, expected := range []uint8{Dead, 1, 2} {
+			if f.values[0][i] != expected {
+				t.Errorf("Expected pixel %d to be state %d, got %d", i, expected, f.values[0][i])
+			}
+		}
+	})
+}
+
+func TestReadImage(t *testing.T) {
+	for _, opts := range []RenderOptions{{}, {CellSize: 5}, {CellSize: 4, Grid: true}} {
+		u := NewUniverse(20, 30)
+		u.Randomize(40)
+
+		buf := &bytes.Buffer{}
+		u.WritePNG(buf, opts)
+
+		u2 := NewUniverse(20, 30)
+		if err := u2.ReadImage(buf, ImportOptions{Scale: opts.CellSize, Grid: opts.Grid}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if u.String() != u2.String() {
+			t.Errorf("Expected the image to round trip with %+v:\n%s\ngot:\n%s", opts, u, u2)
+		}
+	}
+
+	if _, err := ReadImage(strings.NewReader("not an image"), ImportOptions{}); err == nil {
+		t.Errorf("Expected an error for invalid images")
+	}
+}
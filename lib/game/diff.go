@@ -0,0 +1,224 @@
+package game
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+)
+
+// Change is a cell whose state differs between two universes.
+type Change struct {
+	Row, Column   uint32
+	Before, After uint8
+}
+
+// Patch is the list of changes that turns the cells of a universe into the
+// cells of another universe of the same size.
+type Patch struct {
+	Height, Width uint32
+	// Changes are ordered by row, then by column.
+	Changes []Change
+}
+
+// Equal reports whether the universes have the same size and cells. Their
+// generation, rules and boundaries are not compared.
+func (u *Universe) Equal(other *Universe) bool {
+	if u.height != other.height || u.width != other.width {
+		return false
+	}
+
+	for i, cell := range u.cells {
+		if other.cells[i] != cell {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the patch that turns the cells of the universe into the cells
+// of the other universe, which must have the same size.
+func (u *Universe) Diff(other *Universe) (Patch, error) {
+	if u.height != other.height || u.width != other.width {
+		return Patch{}, errInvalidLength
+	}
+
+	return diffCells(u.height, u.width, u.cells, other.cells), nil
+}
+
+// Apply changes the cells of the universe as described by the patch. The
+// patch must be for a universe of the same size, and the cells it changes
+// must be in their Before state, otherwise no cell is changed.
+func (u *Universe) Apply(p Patch) error {
+	if p.Height != u.height || p.Width != u.width {
+		return errInvalidLength
+	}
+	for _, c := range p.Changes {
+		if c.Row >= u.height || c.Column >= u.width || u.cells[u.GetIndex(c.Row, c.Column)] != c.Before {
+			return errPatchMismatch
+		}
+	}
+
+	p.apply(u.cells)
+	u.changed()
+	return nil
+}
+
+// diffCells returns the patch between two sets of cells of the given size.
+func diffCells(height, width uint32, before, after []uint8) Patch {
+	p := Patch{Height: height, Width: width}
+	for i, cell := range after {
+		if cell != before[i] {
+			p.Changes = append(p.Changes, Change{
+				Row:    uint32(i) / width,
+				Column: uint32(i) % width,
+				Before: before[i],
+				After:  cell,
+			})
+		}
+	}
+
+	return p
+}
+
+// apply sets the cells changed by the patch to their After state, without
+// checking their current state.
+func (p Patch) apply(cells []uint8) {
+	for _, c := range p.Changes {
+		cells[c.Row*p.Width+c.Column] = c.After
+	}
+}
+
+// Empty reports whether the patch changes no cells.
+func (p Patch) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Invert returns the patch that undoes this one.
+func (p Patch) Invert() Patch {
+	inverted := Patch{Height: p.Height, Width: p.Width, Changes: make([]Change, len(p.Changes))}
+	for i, c := range p.Changes {
+		inverted.Changes[i] = Change{Row: c.Row, Column: c.Column, Before: c.After, After: c.Before}
+	}
+
+	return inverted
+}
+
+// String lists the changes of the patch, one per line, such as
+// "(3, 4): 0 -> 1".
+func (p Patch) String() string {
+	builder := strings.Builder{}
+	for _, c := range p.Changes {
+		builder.WriteString("(" + strconv.FormatUint(uint64(c.Row), 10) + ", ")
+		builder.WriteString(strconv.FormatUint(uint64(c.Column), 10) + "): ")
+		builder.WriteString(strconv.Itoa(int(c.Before)) + " -> " + strconv.Itoa(int(c.After)) + "\n")
+	}
+
+	return builder.String()
+}
+
+// MarshalBinary encodes the patch compactly: the size of the universe and
+// the number of changes as varints, then for every change the distance
+// from the previous changed cell as a varint, followed by its states. When
+// every state is below 16, as for rules with few states, both states of a
+// change share a single byte.
+func (p Patch) MarshalBinary() ([]byte, error) {
+	packed := byte(1)
+	for _, c := range p.Changes {
+		if c.Before >= 16 || c.After >= 16 {
+			packed = 0
+		}
+	}
+
+	data := binary.AppendUvarint(nil, uint64(p.Height))
+	data = binary.AppendUvarint(data, uint64(p.Width))
+	data = binary.AppendUvarint(data, uint64(len(p.Changes)))
+	data = append(data, packed)
+
+	previous := uint64(0)
+	for i, c := range p.Changes {
+		index := uint64(c.Row)*uint64(p.Width) + uint64(c.Column)
+		if i > 0 && index <= previous {
+			return nil, errPatchMismatch
+		}
+		data = binary.AppendUvarint(data, index-previous)
+		previous = index
+
+		if packed == 1 {
+			data = append(data, c.Before<<4|c.After)
+		} else {
+			data = append(data, c.Before, c.After)
+		}
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary decodes a patch encoded by MarshalBinary.
+func (p *Patch) UnmarshalBinary(data []byte) error {
+	r := patchReader{data: data}
+	height, width, count := r.uvarint(), r.uvarint(), r.uvarint()
+	packed := r.byte()
+	if r.err != nil || height > maxPatchSize || width > maxPatchSize ||
+		count > height*width || count > uint64(len(data)) || packed > 1 {
+		return errInvalidPatch
+	}
+
+	decoded := Patch{Height: uint32(height), Width: uint32(width), Changes: make([]Change, count)}
+	index := uint64(0)
+	for i := range decoded.Changes {
+		gap := r.uvarint()
+		if (i > 0 && gap == 0) || gap >= height*width-index {
+			return errInvalidPatch
+		}
+		index += gap
+
+		c := Change{Row: uint32(index / width), Column: uint32(index % width)}
+		if packed == 1 {
+			states := r.byte()
+			c.Before, c.After = states>>4, states&0xf
+		} else {
+			c.Before, c.After = r.byte(), r.byte()
+		}
+		decoded.Changes[i] = c
+	}
+	if r.err != nil || len(r.data) > 0 {
+		return errInvalidPatch
+	}
+
+	*p = decoded
+	return nil
+}
+
+// maxPatchSize is the largest height or width of a decoded patch.
+const maxPatchSize = 1<<32 - 1
+
+// patchReader reads the fields of an encoded patch, remembering the first
+// error.
+type patchReader struct {
+	data []byte
+	err  error
+}
+
+func (r *patchReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errInvalidPatch
+		r.data = nil
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *patchReader) byte() byte {
+	if len(r.data) == 0 {
+		r.err = errInvalidPatch
+		return 0
+	}
+
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("Equal", func(t *testing.T) {
+		u, u2 := NewUniverse(8, 8), NewUniverse(8, 8)
+		u.SetRectangle(1, 1, Glider().Values())
+		u2.SetRectangle(1, 1, Glider().Values())
+		u2.Generation = 5
+
+		if !u.Equal(u2) {
+			t.Errorf("Expected universes with the same cells to be equal")
+		}
+
+		u2.ToggleCellAt(7, 7)
+		if u.Equal(u2) {
+			t.Errorf("Expected universes with different cells to differ")
+		}
+		if u.Equal(NewUniverse(8, 9)) {
+			t.Errorf("Expected universes of different sizes to differ")
+		}
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		u, u2 := NewUniverse(8, 8), NewUniverse(8, 8)
+		u.SetRectangle(1, 1, Glider().Values())
+		u2.SetRectangle(1, 1, Glider().Values())
+		u2.ToggleCellAt(1, 2)
+		u2.ToggleCellAt(6, 0)
+
+		patch, err := u.Diff(u2)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := "(1, 2): 1 -> 0\n(6, 0): 0 -> 1\n"
+		if patch.String() != expected {
+			t.Errorf("Expected changes\n%s\ngot\n%s", expected, patch)
+		}
+
+		if _, err := u.Diff(NewUniverse(4, 4)); err != errInvalidLength {
+			t.Errorf("Expected error to be %v, got %v", errInvalidLength, err)
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		u, u2 := NewUniverse(16, 16), NewUniverse(16, 16)
+		u.Randomize(40)
+		u2.Randomize(40)
+		before := NewUniverse(16, 16)
+		before.Write(u.cells)
+
+		patch, _ := u.Diff(u2)
+		if err := u.Apply(patch); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !u.Equal(u2) {
+			t.Errorf("Expected the patch to turn u into u2")
+		}
+
+		if err := u.Apply(patch); err != errPatchMismatch {
+			t.Errorf("Expected error to be %v, got %v", errPatchMismatch, err)
+		}
+
+		if err := u.Apply(patch.Invert()); err != nil || !u.Equal(before) {
+			t.Errorf("Expected the inverted patch to undo it, got %v", err)
+		}
+
+		if err := NewUniverse(4, 4).Apply(patch); err != errInvalidLength {
+			t.Errorf("Expected error to be %v, got %v", errInvalidLength, err)
+		}
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		u, u2 := NewUniverse(20, 30), NewUniverse(20, 30)
+		u.Randomize(50)
+		u2.Randomize(50)
+		u2.cells[599] = 20
+
+		for _, other := range []*Universe{u, u2} {
+			patch, _ := NewUniverse(20, 30).Diff(other)
+			data, err := patch.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			var decoded Patch
+			if err := decoded.UnmarshalBinary(data); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if decoded.String() != patch.String() || decoded.Height != 20 || decoded.Width != 30 {
+				t.Errorf("Expected the decoded patch to match, got\n%s", decoded)
+			}
+		}
+
+		patch, _ := NewUniverse(20, 30).Diff(u)
+		data, _ := patch.MarshalBinary()
+		if len(data) > 2*len(patch.Changes)+8 {
+			t.Errorf("Expected about 2 bytes per change, got %d bytes for %d changes", len(data), len(patch.Changes))
+		}
+
+		var decoded Patch
+		for _, invalid := range [][]byte{nil, data[:len(data)-1], append(data, 0), {2, 2, 5, 1, 0}} {
+			if err := decoded.UnmarshalBinary(invalid); err != errInvalidPatch {
+				t.Errorf("Expected error to be %v, got %v", errInvalidPatch, err)
+			}
+		}
+	})
+}
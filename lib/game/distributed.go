@@ -37,14 +37,21 @@ func NewDistributedUniverse(id string, height, width uint32) *DistributedUnivers
 		BottomID: NullID,
 		LeftID:   NullID,
 		RightID:  NullID,
-		Universe: NewUniverse(height, width),
 	}
 
-	d.Rules = d.rules
+	d.attach(NewUniverse(height, width))
 	d.GetNeighbor = d.getEmptyUniverse
 	return d
 }
 
+// attach makes u the grid of the universe, reading the cells beyond its
+// edges from the neighbors.
+func (d *DistributedUniverse) attach(u *Universe) {
+	d.Universe = u
+	d.Rules = d.rules
+	d.outside = d.neighborCell
+}
+
 func (d *DistributedUniverse) rules(cell uint8, row, column uint32) uint8 {
 	return RuleB3S23(cell, d.Neighbors(row, column))
 }
@@ -57,76 +64,12 @@ func (d *DistributedUniverse) Neighbors(row, column uint32) uint8 {
 	r, c := int32(row), int32(column)
 	for _, neighborRow := range []int32{r - 1, r, r + 1} {
 		for _, neighborColumn := range []int32{c - 1, c, c + 1} {
-			switch {
-			case neighborRow == r && neighborColumn == c:
-				// Skip checking the cell itself
+			// Skip checking the cell itself
+			if neighborRow == r && neighborColumn == c {
 				continue
-			case neighborRow < 0 && neighborColumn < 0:
-				// TODO: check the universe above and to the left for 1 pixel?
-			case neighborRow < 0 && neighborColumn >= int32(d.width):
-				// TODO: check the universe above and to the right for 1 pixel?
-			case neighborRow >= int32(d.height) && neighborColumn < 0:
-				// TODO: check the universe below and to the left for 1 pixel?
-			case neighborRow >= int32(d.height) && neighborColumn >= int32(d.width):
-				// check the universe below and to the right for one pixel?
-			case neighborRow < 0:
-				// check the universe above
-				if d.TopID == NullID {
-					continue
-				}
-				if d.TopNeighbor == nil {
-					d.TopNeighbor = d.GetNeighbor(d.TopID)
-				}
-
-				neighborIdx := d.GetIndex(d.height-1, uint32(neighborColumn))
-				if d.TopNeighbor.Cell(neighborIdx) != Dead {
-					count++
-				}
-			case neighborRow >= int32(d.height):
-				// check the universe below
-				if d.BottomID == NullID {
-					continue
-				}
-				if d.BottomNeighbor == nil {
-					d.BottomNeighbor = d.GetNeighbor(d.BottomID)
-				}
-
-				neighborIdx := d.GetIndex(0, uint32(neighborColumn))
-				if d.BottomNeighbor.Cell(neighborIdx) != Dead {
-					count++
-				}
-			case neighborColumn < 0:
-				// check the universe to the left
-				if d.LeftID == NullID {
-					continue
-				}
-				if d.LeftNeighbor == nil {
-					d.LeftNeighbor = d.GetNeighbor(d.LeftID)
-				}
-
-				neighborIdx := d.GetIndex(uint32(neighborRow), d.width-1)
-				if d.LeftNeighbor.Cell(neighborIdx) != Dead {
-					count++
-				}
-			case neighborColumn >= int32(d.width):
-				// check the universe to the right
-				if d.RightID == NullID {
-					continue
-				}
-				if d.RightNeighbor == nil {
-					d.RightNeighbor = d.GetNeighbor(d.RightID)
-				}
-
-				neighborIdx := d.GetIndex(uint32(neighborRow), 0)
-				if d.RightNeighbor.Cell(neighborIdx) != Dead {
-					count++
-				}
-			default:
-				// check the current universe
-				neighborIdx := d.GetIndex(uint32(neighborRow), uint32(neighborColumn))
-				if d.Cell(neighborIdx) != Dead {
-					count++
-				}
+			}
+			if d.cellAt(neighborRow, neighborColumn) != Dead {
+				count++
 			}
 		}
 	}
@@ -134,6 +77,63 @@ func (d *DistributedUniverse) Neighbors(row, column uint32) uint8 {
 	return count
 }
 
+// neighborCell returns the state of a cell just beyond the edges of the
+// universe, fetching the adjoining neighbor Universe on first use.
+func (d *DistributedUniverse) neighborCell(row, column int32) uint8 {
+	switch {
+	case row < 0 && column < 0:
+		// TODO: check the universe above and to the left for 1 pixel?
+	case row < 0 && column >= int32(d.width):
+		// TODO: check the universe above and to the right for 1 pixel?
+	case row >= int32(d.height) && column < 0:
+		// TODO: check the universe below and to the left for 1 pixel?
+	case row >= int32(d.height) && column >= int32(d.width):
+		// check the universe below and to the right for one pixel?
+	case row < 0:
+		// check the universe above
+		if d.TopID == NullID {
+			break
+		}
+		if d.TopNeighbor == nil {
+			d.TopNeighbor = d.GetNeighbor(d.TopID)
+		}
+
+		return d.TopNeighbor.Cell(d.GetIndex(d.height-1, uint32(column)))
+	case row >= int32(d.height):
+		// check the universe below
+		if d.BottomID == NullID {
+			break
+		}
+		if d.BottomNeighbor == nil {
+			d.BottomNeighbor = d.GetNeighbor(d.BottomID)
+		}
+
+		return d.BottomNeighbor.Cell(d.GetIndex(0, uint32(column)))
+	case column < 0:
+		// check the universe to the left
+		if d.LeftID == NullID {
+			break
+		}
+		if d.LeftNeighbor == nil {
+			d.LeftNeighbor = d.GetNeighbor(d.LeftID)
+		}
+
+		return d.LeftNeighbor.Cell(d.GetIndex(uint32(row), d.width-1))
+	case column >= int32(d.width):
+		// check the universe to the right
+		if d.RightID == NullID {
+			break
+		}
+		if d.RightNeighbor == nil {
+			d.RightNeighbor = d.GetNeighbor(d.RightID)
+		}
+
+		return d.RightNeighbor.Cell(d.GetIndex(uint32(row), 0))
+	}
+
+	return Dead
+}
+
 func (d *DistributedUniverse) SetTopNeighbor(id string) error {
 	if d.ID == id {
 		return errInvalidID
@@ -201,10 +201,13 @@ func (d *DistributedUniverse) Write(p []byte) (n int, err error) {
 	d.LeftID = string(p[96:128])
 	d.RightID = string(p[128:160])
 	copy(d.cells, p[160:])
+	d.changed()
 
 	return len(p), nil
 }
 
+// Bytes returns the IDs and raw cells of the universe, as read by Write.
+// See MarshalBinary for a versioned and compressed snapshot.
 func (d *DistributedUniverse) Bytes() []byte {
 	buf := make([]byte, IDLength*5+len(d.cells))
 	d.Read(buf)
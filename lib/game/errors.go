@@ -2,10 +2,48 @@ package game
 
 import (
 	"errors"
+	"strconv"
 )
 
 var (
-	errInvalidLength    = errors.New("slice does not match universe size")
-	errInvalidID        = errors.New("IDs cannot be the same")
-	errInvalidCharacter = errors.New("cannot parse invalid character")
+	errInvalidLength      = errors.New("slice does not match universe size")
+	errInvalidID          = errors.New("IDs cannot be the same")
+	errInvalidCharacter   = errors.New("cannot parse invalid character")
+	errInvalidRule        = errors.New("cannot parse invalid rulestring")
+	errInvalidHeader      = errors.New("cannot parse invalid pattern header")
+	errInvalidNode        = errors.New("cannot parse invalid macrocell node")
+	errInvalidBoundary    = errors.New("invalid boundary")
+	errUnknownPattern     = errors.New("no pattern with this name in the catalog")
+	errInvalidBlendMode   = errors.New("invalid blend mode")
+	errOverlappingFigures = errors.New("figures have overlapping live cells")
+	errInvalidDensity     = errors.New("density must be between 0 and 100")
+	errInvalidRegion      = errors.New("region does not fit the universe or its symmetry")
+	errInvalidSymmetry    = errors.New("invalid symmetry")
+	errInvalidNoise       = errors.New("invalid noise")
+	errGenerationTooEarly = errors.New("generation is before the start of the history")
+	errPatchMismatch      = errors.New("patch does not match the cells of the universe")
+	errInvalidPatch       = errors.New("cannot decode invalid patch")
+	errInvalidAnchor      = errors.New("invalid anchor")
+	errResizeNeighbors    = errors.New("universes with neighbors cannot be resized")
+	errEmptyUniverse      = errors.New("universe has no live cells")
 )
+
+// Errors returned when loading snapshots.
+var (
+	ErrSnapshotMagic   = errors.New("not a vita snapshot")
+	ErrSnapshotVersion = errors.New("unsupported snapshot version")
+	ErrSnapshotKind    = errors.New("snapshot is of another kind of universe")
+	ErrSnapshotCorrupt = errors.New("snapshot is corrupted")
+)
+
+// SnapshotSizeError is returned when loading a snapshot into a universe of
+// a different size.
+type SnapshotSizeError struct {
+	Height, Width         uint32
+	WantHeight, WantWidth uint32
+}
+
+func (e *SnapshotSizeError) Error() string {
+	return "snapshot is " + strconv.FormatUint(uint64(e.Height), 10) + "x" + strconv.FormatUint(uint64(e.Width), 10) +
+		", universe is " + strconv.FormatUint(uint64(e.WantHeight), 10) + "x" + strconv.FormatUint(uint64(e.WantWidth), 10)
+}
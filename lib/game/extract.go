@@ -0,0 +1,55 @@
+package game
+
+// Component is a group of live cells of a universe, positioned by the
+// top-left corner of its bounding box.
+type Component struct {
+	Row, Column int
+	Figure      *Figure
+}
+
+// Extract returns the cells of a region of the universe, trimmed to their
+// live cells, and the position of the top-left corner of the result. The
+// region may extend beyond the edges, which are handled like in Tick.
+// The figure is empty if the region has no live cells.
+func (u *Universe) Extract(row, column, height, width int) (f *Figure, top, left int) {
+	values := make([][]uint8, max(height, 0))
+	for r := range values {
+		values[r] = make([]uint8, max(width, 0))
+		for c := range values[r] {
+			values[r][c] = u.cellAt(int32(row+r), int32(column+c))
+		}
+	}
+
+	trimmed, top, left := trimValues(values)
+	return NewFigure(trimmed), row + top, column + left
+}
+
+// Components separates the live cells of the universe into objects, like
+// Census: cells that are less than three cells apart belong to the same
+// object. Components are ordered by the position of their first cell.
+func (u *Universe) Components() []Component {
+	objects := u.objects()
+	components := make([]Component, len(objects))
+	for i, o := range objects {
+		components[i] = Component{Row: o.row, Column: o.column, Figure: NewFigure(o.values)}
+	}
+
+	return components
+}
+
+// ComponentAt returns the component of Components containing the live cell
+// at the given position. It returns false if the cell is dead or outside of
+// the universe.
+func (u *Universe) ComponentAt(row, column int) (Component, bool) {
+	if row < 0 || row >= int(u.height) || column < 0 || column >= int(u.width) {
+		return Component{}, false
+	}
+
+	start := row*int(u.width) + column
+	if u.cells[start] == Dead {
+		return Component{}, false
+	}
+
+	o := u.object(start, make([]bool, len(u.cells)))
+	return Component{Row: o.row, Column: o.column, Figure: NewFigure(o.values)}, true
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	t.Run("Region", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.StampFigure(5, 6, Glider(), BlendOr)
+
+		f, row, column := u.Extract(2, 2, 10, 10)
+		if f.String() != Glider().String() || row != 4 || column != 5 {
+			t.Errorf("Expected the glider at (4, 5), got (%d, %d):\n%s", row, column, f)
+		}
+
+		u2 := NewUniverse(16, 16)
+		u2.Stamp(row, column, f.Values(), BlendOr)
+		if u2.String() != u.String() {
+			t.Errorf("Expected the extracted figure to stamp back in place:\n%s\ngot:\n%s", u, u2)
+		}
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.StampFigure(5, 6, Glider(), BlendOr)
+
+		f, row, column := u.Extract(6, 0, 1, 16)
+		if f.String() != "OOO\n" || row != 6 || column != 5 {
+			t.Errorf("Expected the last row of the glider at (6, 5), got (%d, %d):\n%s", row, column, f)
+		}
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		u.Boundary = BoundaryWrap
+		u.StampFigure(0, 0, Glider(), BlendOr)
+
+		f, row, column := u.Extract(-4, -4, 8, 8)
+		if f.String() != Glider().String() || row != -1 || column != -1 {
+			t.Errorf("Expected the glider across the edges at (-1, -1), got (%d, %d):\n%s", row, column, f)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if f, _, _ := NewUniverse(8, 8).Extract(0, 0, 8, 8); f.Height() != 0 {
+			t.Errorf("Expected an empty figure, got:\n%s", f)
+		}
+	})
+}
+
+func TestComponents(t *testing.T) {
+	u := NewUniverse(16, 16)
+	u.StampFigure(3, 3, Glider(), BlendOr)
+	u.StampFigure(10, 10, Beehive(), BlendOr)
+
+	components := u.Components()
+	if len(components) != 2 {
+		t.Fatalf("Expected two components, got %d", len(components))
+	}
+	if components[0].Figure.String() != Glider().String() || components[0].Row != 2 || components[0].Column != 2 {
+		t.Errorf("Expected the glider at (2, 2), got (%d, %d):\n%s", components[0].Row, components[0].Column, components[0].Figure)
+	}
+	if Classify(components[1].Figure, nil) != "xs6_696" {
+		t.Errorf("Expected the second component to be a beehive, got:\n%s", components[1].Figure)
+	}
+
+	c, ok := u.ComponentAt(11, 11)
+	if !ok || c.Figure.String() != Beehive().String() || c.Row != 9 || c.Column != 9 {
+		t.Errorf("Expected the beehive at (9, 9), got %v at (%d, %d)", ok, c.Row, c.Column)
+	}
+	if _, ok := u.ComponentAt(0, 0); ok {
+		t.Errorf("Expected no component on a dead cell")
+	}
+	if _, ok := u.ComponentAt(-1, 20); ok {
+		t.Errorf("Expected no component outside of the universe")
+	}
+}
@@ -1,23 +1,238 @@
 package game
 
+import (
+	"strings"
+)
+
 type Figure struct {
-	deltaX uint32
-	deltaY uint32
+	// deltaX and deltaY are the row and column of the anchor, which may
+	// be outside of the cells after Advance.
+	deltaX int
+	deltaY int
 	values [][]uint8
 }
 
+// NewFigure returns a Figure with the given rows of cells, anchored
+// at its center.
+func NewFigure(values [][]uint8) *Figure {
+	f := &Figure{values: values}
+	if h := f.Height(); h > 0 {
+		f.deltaX = int(h-1) / 2
+	}
+	if w := f.Width(); w > 0 {
+		f.deltaY = int(w-1) / 2
+	}
+
+	return f
+}
+
+// DeltaX returns the row of the anchor, or 0 if it is above the figure, see
+// Anchor.
 func (f *Figure) DeltaX() uint32 {
-	return f.deltaX
+	return uint32(max(f.deltaX, 0))
 }
 
+// DeltaY returns the column of the anchor, or 0 if it is left of the figure,
+// see Anchor.
 func (f *Figure) DeltaY() uint32 {
-	return f.deltaY
+	return uint32(max(f.deltaY, 0))
+}
+
+// Anchor returns the row and column of the anchor relative to the top-left
+// cell of the figure, which are negative if it is above or left of it.
+func (f *Figure) Anchor() (row, column int) {
+	return f.deltaX, f.deltaY
 }
 
 func (f *Figure) Values() [][]uint8 {
 	return f.values
 }
 
+func (f *Figure) Height() uint32 {
+	return uint32(len(f.values))
+}
+
+// Width returns the length of the longest row of the figure.
+func (f *Figure) Width() uint32 {
+	var width int
+	for _, row := range f.values {
+		width = max(width, len(row))
+	}
+
+	return uint32(width)
+}
+
+// String renders the figure in the same plaintext format as Universe.
+func (f *Figure) String() string {
+	builder := strings.Builder{}
+	width := int(f.Width())
+	for _, row := range f.values {
+		for column := 0; column < width; column++ {
+			if column < len(row) && row[column] != Dead {
+				builder.WriteString("O")
+			} else {
+				builder.WriteString(".")
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// trimValues removes the dead rows and columns around the live cells, and
+// returns the position of the remaining rectangle within the original one.
+func trimValues(values [][]uint8) (trimmed [][]uint8, row, column int) {
+	top, left, bottom, right := -1, -1, -1, -1
+	for r, cells := range values {
+		for c, cell := range cells {
+			if cell == Dead {
+				continue
+			}
+			if top < 0 {
+				top = r
+			}
+			if left < 0 || c < left {
+				left = c
+			}
+			bottom = r
+			right = max(right, c)
+		}
+	}
+	if top < 0 {
+		return [][]uint8{}, 0, 0
+	}
+
+	trimmed = make([][]uint8, bottom-top+1)
+	for r := range trimmed {
+		trimmed[r] = make([]uint8, right-left+1)
+		row := values[top+r]
+		if left < len(row) {
+			copy(trimmed[r], row[left:min(len(row), right+1)])
+		}
+	}
+
+	return trimmed, top, left
+}
+
+// rotateValues rotates the cells by 90 degrees clockwise.
+func rotateValues(values [][]uint8) [][]uint8 {
+	height, width := len(values), 0
+	for _, row := range values {
+		width = max(width, len(row))
+	}
+
+	rotated := make([][]uint8, width)
+	for r := range rotated {
+		rotated[r] = make([]uint8, height)
+		for c := range rotated[r] {
+			if row := values[height-1-c]; r < len(row) {
+				rotated[r][c] = row[r]
+			}
+		}
+	}
+
+	return rotated
+}
+
+// reflectValues mirrors the cells horizontally.
+func reflectValues(values [][]uint8) [][]uint8 {
+	width := 0
+	for _, row := range values {
+		width = max(width, len(row))
+	}
+
+	reflected := make([][]uint8, len(values))
+	for r, row := range values {
+		reflected[r] = make([]uint8, width)
+		for c, cell := range row {
+			reflected[r][width-1-c] = cell
+		}
+	}
+
+	return reflected
+}
+
+// Rotate returns the figure rotated clockwise by the given number of
+// quarter turns, which may be negative. The anchor stays on the same cell.
+// An empty figure is returned unchanged.
+func (f *Figure) Rotate(quarterTurns int) *Figure {
+	r := &Figure{deltaX: f.deltaX, deltaY: f.deltaY, values: f.values}
+	if f.Height() == 0 {
+		return r
+	}
+	for i := 0; i < (quarterTurns%4+4)%4; i++ {
+		height := int(r.Height())
+		r = &Figure{deltaX: r.deltaY, deltaY: height - 1 - r.deltaX, values: rotateValues(r.values)}
+	}
+
+	return r
+}
+
+// FlipHorizontal returns the figure mirrored left to right. The anchor stays
+// on the same cell. An empty figure is returned unchanged.
+func (f *Figure) FlipHorizontal() *Figure {
+	if f.Height() == 0 {
+		return &Figure{deltaX: f.deltaX, deltaY: f.deltaY, values: f.values}
+	}
+
+	return &Figure{deltaX: f.deltaX, deltaY: int(f.Width()) - 1 - f.deltaY, values: reflectValues(f.values)}
+}
+
+// FlipVertical returns the figure mirrored top to bottom. The anchor stays
+// on the same cell. An empty figure is returned unchanged.
+func (f *Figure) FlipVertical() *Figure {
+	if f.Height() == 0 {
+		return &Figure{deltaX: f.deltaX, deltaY: f.deltaY, values: f.values}
+	}
+
+	flipped := make([][]uint8, len(f.values))
+	for r, row := range f.values {
+		flipped[len(f.values)-1-r] = append([]uint8{}, row...)
+	}
+
+	return &Figure{deltaX: int(f.Height()) - 1 - f.deltaX, deltaY: f.deltaY, values: flipped}
+}
+
+// Transpose returns the figure mirrored along its main diagonal, so that
+// rows become columns. The anchor stays on the same cell.
+func (f *Figure) Transpose() *Figure {
+	return f.Rotate(1).FlipHorizontal()
+}
+
+// Advance returns the figure after evolving in isolation for the given
+// number of generations under the rule, or B3/S23 if rule is nil, trimmed
+// to its live cells. The anchor follows the cells, so that spaceships are
+// placed further along their path, even if it ends up outside of them.
+func (f *Figure) Advance(generations int, rule *Rule) *Figure {
+	values, row, column := f.advance(generations, rule)
+	if len(values) == 0 {
+		return &Figure{values: values}
+	}
+
+	return &Figure{deltaX: row, deltaY: column, values: values}
+}
+
+// advance returns the cells of the figure after evolving in isolation for
+// the given number of generations, trimmed to its live cells, and the
+// position of the anchor relative to their top-left cell, which may be
+// outside of them.
+func (f *Figure) advance(generations int, rule *Rule) (values [][]uint8, row, column int) {
+	if rule == nil {
+		rule = conwayRule
+	}
+
+	values, top, left := trimValues(f.values)
+	row, column = f.deltaX-top, f.deltaY-left
+	for i := 0; i < generations && len(values) > 0; i++ {
+		var r, c int
+		values, r, c = step(values, rule)
+		row, column = row-r, column-c
+	}
+
+	return values, row, column
+}
+
 // Inspired by: https://www.reddit.com/r/rust/comments/5penft/comment/dcsq64p
 // If you look closely, those aren't angle brackets,
 // they're characters from the Canadian Aboriginal Syllabics block,
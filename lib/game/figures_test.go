@@ -0,0 +1,89 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestFigureTransformations(t *testing.T) {
+	// An L shape anchored on its corner.
+	f := &Figure{deltaX: 2, deltaY: 0, values: [][]uint8{
+		{Alive, Dead},
+		{Alive, Dead},
+		{Alive, Alive},
+	}}
+
+	for name, test := range map[string]struct {
+		figure         *Figure
+		expected       string
+		deltaX, deltaY uint32
+	}{
+		"Rotate 90":       {f.Rotate(1), "OOO\nO..\n", 0, 0},
+		"Rotate 180":      {f.Rotate(2), "OO\n.O\n.O\n", 0, 1},
+		"Rotate 270":      {f.Rotate(3), "..O\nOOO\n", 1, 2},
+		"Rotate -90":      {f.Rotate(-1), "..O\nOOO\n", 1, 2},
+		"Rotate 360":      {f.Rotate(4), "O.\nO.\nOO\n", 2, 0},
+		"Flip horizontal": {f.FlipHorizontal(), ".O\n.O\nOO\n", 2, 1},
+		"Flip vertical":   {f.FlipVertical(), "OO\nO.\nO.\n", 0, 0},
+		"Transpose":       {f.Transpose(), "OOO\n..O\n", 0, 2},
+	} {
+		if test.figure.String() != test.expected {
+			t.Errorf("Expected %s to be:\n%s\ngot:\n%s", name, test.expected, test.figure)
+		}
+		if test.figure.DeltaX() != test.deltaX || test.figure.DeltaY() != test.deltaY {
+			t.Errorf("Expected %s to be anchored at (%d, %d), got (%d, %d)",
+				name, test.deltaX, test.deltaY, test.figure.DeltaX(), test.figure.DeltaY())
+		}
+	}
+
+	if f.String() != "O.\nO.\nOO\n" {
+		t.Errorf("Expected the figure to be unchanged, got:\n%s", f)
+	}
+
+	empty := NewFigure(nil)
+	for _, e := range []*Figure{empty.Rotate(1), empty.Rotate(3), empty.FlipHorizontal(), empty.FlipVertical(), empty.Transpose()} {
+		if row, column := e.Anchor(); e.Height() != 0 || row != 0 || column != 0 {
+			t.Errorf("Expected an empty figure anchored at (0, 0), got (%d, %d)", row, column)
+		}
+	}
+}
+
+func TestFigureAdvance(t *testing.T) {
+	t.Run("Same as Tick", func(t *testing.T) {
+		for _, f := range []*Figure{Glider(), Glider().Rotate(1), Glider().FlipVertical(), Pulsar()} {
+			for generations := 0; generations < 13; generations++ {
+				u := NewUniverse(32, 32)
+				u.StampFigure(16, 16, f, BlendOr)
+				for i := 0; i < generations; i++ {
+					u.Tick()
+				}
+
+				a := f.Advance(generations, nil)
+				u2 := NewUniverse(32, 32)
+				u2.StampFigure(16, 16, a, BlendOr)
+				if u.String() != u2.String() {
+					t.Errorf("Expected advancing %d generations to match the universe:\n%s\ngot:\n%s", generations, u, u2)
+				}
+			}
+		}
+	})
+
+	t.Run("Period", func(t *testing.T) {
+		if a := Glider().Advance(4, nil); a.String() != Glider().String() {
+			t.Errorf("Expected the glider after a period, got:\n%s", a)
+		}
+	})
+
+	t.Run("Anchor outside", func(t *testing.T) {
+		a := Glider().Advance(12, nil)
+		if row, column := a.Anchor(); row != -2 || column != -2 || a.DeltaX() != 0 || a.DeltaY() != 0 {
+			t.Errorf("Expected the anchor 3 cells up and left of the center, got (%d, %d)", row, column)
+		}
+	})
+
+	t.Run("Dies", func(t *testing.T) {
+		f := NewFigure([][]uint8{{Alive, Alive}})
+		if a := f.Advance(1, nil); a.Height() != 0 || a.DeltaX() != 0 {
+			t.Errorf("Expected an empty figure, got:\n%s", a)
+		}
+	})
+}
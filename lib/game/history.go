@@ -0,0 +1,393 @@
+package game
+
+const (
+	// defaultSnapshotInterval is how many generations apart History takes
+	// frames when it is not given an interval.
+	defaultSnapshotInterval = 32
+
+	// keyframeInterval is how many frames are stored as changes from
+	// the same full copy of the cells before taking another full copy.
+	keyframeInterval = 16
+
+	// changeSize is the memory taken by a Change, against one byte per cell
+	// for a full copy of the cells.
+	changeSize = 12
+
+	// maxFrames is how many frames History keeps before dropping the oldest.
+	maxFrames = 1024
+
+	// maxHistorySize is how many bytes the frames and edits of History can
+	// take before it drops the oldest.
+	maxHistorySize = 64 << 20
+)
+
+// frame is a snapshot of a universe at one generation. Keyframes keep a full
+// copy of the cells, the other frames keep the changes from the keyframe
+// before them, which they share the cells of.
+type frame struct {
+	generation uint32
+	cells      []uint8
+	patch      Patch
+	// deltas is how many frames since the keyframe are stored as changes,
+	// including this one, or 0 for a keyframe.
+	deltas int
+}
+
+// edit is a change to the cells of a universe made through History.
+type edit struct {
+	// from and to are the generations of the universe before and after the
+	// edit, which only differ for Reset.
+	from, to uint32
+	patch    Patch
+	// before and after are the cells on either side of an edit that
+	// changed the size of the universe, which a patch cannot describe.
+	before, after *grid
+	// frame is the index of the snapshot taken after the edit, and
+	// replaced are the frames it replaced, to be restored by Undo.
+	frame    int
+	replaced []frame
+}
+
+// grid is a copy of the cells of a universe of a given size.
+type grid struct {
+	height, width uint32
+	cells         []uint8
+}
+
+// History records the edits and the evolution of a universe, so that edits
+// can be undone and redone and the universe can go back to any generation
+// since the history started. Edits and ticks have to go through History to
+// be recorded.
+//
+// Every few generations History takes a snapshot of the cells, which it
+// stores as the changes from a full copy taken every few frames, unless
+// so many cells changed that a full copy is smaller. Going to a generation
+// restores the closest snapshot before it and simulates the rest with the
+// current rule of the universe.
+//
+// Only the last 1024 snapshots are kept. When the snapshots and the edits,
+// with the frames they replaced, take more than 64 MiB, the oldest edits
+// that can be undone or redone are dropped, then the oldest snapshots.
+type History struct {
+	universe *Universe
+	interval uint32
+	frames   []frame
+	undo     []edit
+	redo     []edit
+	// budget is the number of bytes the history can take.
+	budget int
+}
+
+// NewHistory starts recording the history of the universe from its current
+// generation, taking a snapshot every interval generations, or every 32
+// generations if interval is 0.
+func NewHistory(u *Universe, interval uint32) *History {
+	if interval == 0 {
+		interval = defaultSnapshotInterval
+	}
+
+	h := &History{universe: u, interval: interval, budget: maxHistorySize}
+	h.capture()
+	return h
+}
+
+// Universe returns the universe whose history is recorded.
+func (h *History) Universe() *Universe {
+	return h.universe
+}
+
+// Tick advances the universe by one generation, taking a snapshot if it is
+// the first time the history reaches a multiple of the interval.
+func (h *History) Tick() {
+	h.universe.Tick()
+
+	last := h.frames[len(h.frames)-1].generation
+	if h.universe.Generation%h.interval == 0 && h.universe.Generation > last {
+		h.capture()
+		h.trim()
+	}
+}
+
+// Earliest returns the earliest generation the history can go back to.
+func (h *History) Earliest() uint32 {
+	return h.frames[0].generation
+}
+
+// Stats returns the counts of the current generation of the universe.
+func (h *History) Stats() Stats {
+	return h.universe.Stats()
+}
+
+// Stable reports whether the last generation of the universe changed no cell.
+func (h *History) Stable() bool {
+	return h.universe.Stable()
+}
+
+// Hash returns the hash of the cells of the universe.
+func (h *History) Hash() uint64 {
+	return h.universe.Hash()
+}
+
+// ToggleCellAt toggles a cell of the universe and records it as an edit.
+func (h *History) ToggleCellAt(row, column uint32) {
+	h.Edit(func(u *Universe) { u.ToggleCellAt(row, column) })
+}
+
+// SetRectangle sets a rectangle of cells of the universe and records it as
+// an edit.
+func (h *History) SetRectangle(startingRow, startingColumn uint32, values [][]uint8) {
+	h.Edit(func(u *Universe) { u.SetRectangle(startingRow, startingColumn, values) })
+}
+
+// Randomize fills the universe with random cells and records it as an edit.
+func (h *History) Randomize(livePopulation int) {
+	h.Edit(func(u *Universe) { u.Randomize(livePopulation) })
+}
+
+// Reset kills every cell of the universe and sets its generation back to 0,
+// recording it as an edit, so that it can be undone.
+func (h *History) Reset() {
+	h.Edit(func(u *Universe) { u.Reset() })
+}
+
+// Edit calls fn to change the cells of the universe and records the cells
+// it changed as an edit. Edits that change nothing are not recorded. Any
+// edit that was undone can no longer be redone. Edits that change the size
+// of the universe, such as Resize, keep a copy of the cells before and
+// after them, and the generations before them can only be gone back to by
+// undoing them.
+func (h *History) Edit(fn func(u *Universe)) {
+	u := h.universe
+	before := &grid{height: u.height, width: u.width, cells: append([]uint8(nil), u.cells...)}
+	from := u.Generation
+
+	fn(u)
+
+	e := edit{from: from, to: u.Generation}
+	if u.height != before.height || u.width != before.width {
+		e.before = before
+		e.after = &grid{height: u.height, width: u.width, cells: append([]uint8(nil), u.cells...)}
+	} else {
+		e.patch = diffCells(u.height, u.width, before.cells, u.cells)
+		if e.patch.Empty() && e.from == e.to {
+			return
+		}
+	}
+
+	h.record(e)
+	h.redo = nil
+}
+
+// record replaces the frames that came after the edit with a snapshot of
+// the edited universe, and pushes the edit onto the undo stack. An edit
+// that changed the size of the universe replaces every frame.
+func (h *History) record(e edit) {
+	cut := len(h.frames)
+	for cut > 0 && (e.after != nil || h.frames[cut-1].generation > e.to) {
+		cut--
+	}
+
+	e.replaced = append([]frame(nil), h.frames[cut:]...)
+	e.frame = cut
+	h.frames = h.frames[:cut]
+	h.capture()
+	h.undo = append(h.undo, e)
+	h.trim()
+}
+
+// Undo reverts the last edit, putting the universe back in the state and
+// generation it was in just before it. It returns false if there are no
+// edits to undo.
+func (h *History) Undo() bool {
+	if len(h.undo) == 0 {
+		return false
+	}
+
+	e := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+
+	if e.before != nil {
+		h.frames = append(h.frames[:e.frame], e.replaced...)
+		h.restore(e.from, e.before)
+	} else {
+		cells := h.cells(e.frame)
+		e.patch.Invert().apply(cells)
+		h.frames = append(h.frames[:e.frame], e.replaced...)
+		h.restore(e.from, &grid{height: e.patch.Height, width: e.patch.Width, cells: cells})
+	}
+
+	h.redo = append(h.redo, edit{from: e.from, to: e.to, patch: e.patch, before: e.before, after: e.after})
+	return true
+}
+
+// Redo applies the last undone edit again, at the generation it was first
+// made. It returns false if there are no edits to redo.
+func (h *History) Redo() bool {
+	if len(h.redo) == 0 {
+		return false
+	}
+
+	e := h.redo[len(h.redo)-1]
+	if err := h.SeekGeneration(e.from); err != nil {
+		return false
+	}
+	h.redo = h.redo[:len(h.redo)-1]
+
+	if e.after != nil {
+		h.restore(e.to, e.after)
+	} else {
+		u := h.universe
+		cells := append([]uint8(nil), u.cells...)
+		e.patch.apply(cells)
+		h.restore(e.to, &grid{height: u.height, width: u.width, cells: cells})
+	}
+
+	h.record(e)
+	return true
+}
+
+// CanUndo reports whether there are edits to undo.
+func (h *History) CanUndo() bool {
+	return len(h.undo) > 0
+}
+
+// CanRedo reports whether there are undone edits to redo.
+func (h *History) CanRedo() bool {
+	return len(h.redo) > 0
+}
+
+// SeekGeneration puts the universe in the state it had, or will have, at
+// the given generation, taking into account the edits made so far. It
+// restores the closest snapshot before the generation and simulates the
+// generations after it, so going forward may take as long as ticking.
+func (h *History) SeekGeneration(generation uint32) error {
+	if generation < h.frames[0].generation {
+		return errGenerationTooEarly
+	}
+
+	// The last snapshot of a generation is the most recent, taken after
+	// any edit made at that generation.
+	i := len(h.frames) - 1
+	for h.frames[i].generation > generation {
+		i--
+	}
+
+	// The universe is always in the latest state of its generation, so it
+	// can be simulated from when it is between the snapshot and the target.
+	u := h.universe
+	if u.Generation < h.frames[i].generation || u.Generation > generation {
+		h.restore(h.frames[i].generation, &grid{height: u.height, width: u.width, cells: h.cells(i)})
+	}
+
+	for u.Generation < generation {
+		h.Tick()
+	}
+
+	return nil
+}
+
+// capture takes a snapshot of the current state of the universe.
+func (h *History) capture() {
+	u := h.universe
+	f := frame{generation: u.Generation}
+	if n := len(h.frames); n > 0 && h.frames[n-1].deltas+1 < keyframeInterval {
+		last := h.frames[n-1]
+		f.cells, f.deltas = last.cells, last.deltas+1
+		f.patch = diffCells(u.height, u.width, f.cells, u.cells)
+	}
+
+	if f.cells == nil || len(f.patch.Changes)*changeSize > len(u.cells) {
+		f = frame{generation: u.Generation, cells: append([]uint8(nil), u.cells...)}
+	}
+
+	h.frames = append(h.frames, f)
+}
+
+// trim drops the oldest frames beyond maxFrames, then the oldest edits and
+// frames while the history takes more than its budget.
+func (h *History) trim() {
+	if dropped := len(h.frames) - maxFrames; dropped > 0 {
+		h.dropFrames(dropped)
+	}
+
+	for h.size() > h.budget {
+		switch {
+		case len(h.undo) > 0:
+			h.undo = append([]edit(nil), h.undo[1:]...)
+		case len(h.redo) > 0:
+			h.redo = append([]edit(nil), h.redo[1:]...)
+		case len(h.frames) > 1:
+			h.dropFrames(1)
+		default:
+			return
+		}
+	}
+}
+
+// dropFrames drops the given number of oldest frames. Edits whose snapshot
+// was dropped, and the ones before them, can no longer be undone.
+func (h *History) dropFrames(dropped int) {
+	h.frames = append([]frame(nil), h.frames[dropped:]...)
+
+	kept := 0
+	for i, e := range h.undo {
+		if e.frame < dropped {
+			kept = i + 1
+		}
+	}
+	h.undo = append([]edit(nil), h.undo[kept:]...)
+	for i := range h.undo {
+		h.undo[i].frame -= dropped
+	}
+}
+
+// size returns the number of bytes taken by the cells of the frames and
+// edits of the history. Keyframes shared by other frames are counted once.
+func (h *History) size() int {
+	size := 0
+	keyframes := map[*uint8]bool{}
+	addFrames := func(frames []frame) {
+		for _, f := range frames {
+			size += len(f.patch.Changes) * changeSize
+			if len(f.cells) > 0 && !keyframes[&f.cells[0]] {
+				keyframes[&f.cells[0]] = true
+				size += len(f.cells)
+			}
+		}
+	}
+
+	addFrames(h.frames)
+	for _, edits := range [][]edit{h.undo, h.redo} {
+		for _, e := range edits {
+			size += len(e.patch.Changes) * changeSize
+			if e.before != nil {
+				size += len(e.before.cells) + len(e.after.cells)
+			}
+			addFrames(e.replaced)
+		}
+	}
+
+	return size
+}
+
+// cells returns a copy of the cells of the snapshot at index i.
+func (h *History) cells(i int) []uint8 {
+	f := h.frames[i]
+	cells := append([]uint8(nil), f.cells...)
+	f.patch.apply(cells)
+
+	return cells
+}
+
+// restore puts the universe in the given state without recording it.
+func (h *History) restore(generation uint32, g *grid) {
+	u := h.universe
+	if u.height != g.height || u.width != g.width {
+		u.allocate(g.height, g.width)
+	}
+	copy(u.cells, g.cells)
+	u.Generation = generation
+	u.stable = false
+	u.stats = Stats{}
+	u.changed()
+}
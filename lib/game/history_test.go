@@ -0,0 +1,289 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	t.Run("Undo and redo", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		h := NewHistory(u, 0)
+		h.SetRectangle(1, 1, Glider().Values())
+		glider := u.String()
+		h.ToggleCellAt(0, 0)
+		toggled := u.String()
+
+		if !h.Undo() || u.String() != glider {
+			t.Errorf("Expected undo to remove the toggled cell, got\n%s", u)
+		}
+		if !h.Undo() || u.Population() != 0 {
+			t.Errorf("Expected undo to remove the glider, got\n%s", u)
+		}
+		if h.Undo() {
+			t.Errorf("Expected nothing left to undo")
+		}
+
+		if !h.Redo() || u.String() != glider {
+			t.Errorf("Expected redo to add the glider, got\n%s", u)
+		}
+		if !h.Redo() || u.String() != toggled {
+			t.Errorf("Expected redo to toggle the cell, got\n%s", u)
+		}
+		if h.Redo() {
+			t.Errorf("Expected nothing left to redo")
+		}
+	})
+
+	t.Run("New edits clear redo", func(t *testing.T) {
+		h := NewHistory(NewUniverse(8, 8), 0)
+		h.ToggleCellAt(1, 1)
+		h.Undo()
+		h.ToggleCellAt(2, 2)
+
+		if h.CanRedo() {
+			t.Errorf("Expected no edit to redo")
+		}
+	})
+
+	t.Run("Undo reset", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		h := NewHistory(u, 4)
+		h.Randomize(40)
+		for i := 0; i < 10; i++ {
+			h.Tick()
+		}
+		before := u.String()
+
+		h.Reset()
+		if u.Generation != 0 || u.Population() != 0 {
+			t.Errorf("Expected an empty universe at generation 0, got %d\n%s", u.Generation, u)
+		}
+
+		h.Undo()
+		if u.Generation != 10 || u.String() != before {
+			t.Errorf("Expected generation 10 back, got %d\n%s", u.Generation, u)
+		}
+
+		h.Redo()
+		if u.Generation != 0 || u.Population() != 0 {
+			t.Errorf("Expected the reset again, got %d\n%s", u.Generation, u)
+		}
+	})
+
+	t.Run("Undo after ticking", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		h := NewHistory(u, 4)
+		h.SetRectangle(1, 1, Glider().Values())
+		for i := 0; i < 5; i++ {
+			h.Tick()
+		}
+		before := u.String()
+		h.SetRectangle(10, 10, Beehive().Values())
+		for i := 0; i < 6; i++ {
+			h.Tick()
+		}
+
+		h.Undo()
+		if u.Generation != 5 || u.String() != before {
+			t.Errorf("Expected the universe before the beehive, got %d\n%s", u.Generation, u)
+		}
+	})
+
+	t.Run("Seek", func(t *testing.T) {
+		u, expected := NewUniverse(24, 24), NewUniverse(24, 24)
+		u.Randomize(35)
+		expected.SetRectangle(0, 0, u.rows())
+		h := NewHistory(u, 3)
+
+		states := []string{expected.String()}
+		for i := 0; i < 40; i++ {
+			h.Tick()
+			expected.Tick()
+			states = append(states, expected.String())
+		}
+
+		for _, generation := range []uint32{7, 0, 33, 40, 12, 12, 39} {
+			if err := h.SeekGeneration(generation); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if u.Generation != generation || u.String() != states[generation] {
+				t.Errorf("Expected generation %d, got %d\n%s", generation, u.Generation, u)
+			}
+		}
+
+		if err := h.SeekGeneration(45); err != nil || u.Generation != 45 {
+			t.Errorf("Expected to simulate up to generation 45, got %d and %v", u.Generation, err)
+		}
+	})
+
+	t.Run("Seek with edits", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		h := NewHistory(u, 4)
+		for i := 0; i < 6; i++ {
+			h.Tick()
+		}
+		h.SetRectangle(5, 5, Beehive().Values())
+		for i := 0; i < 6; i++ {
+			h.Tick()
+		}
+
+		h.SeekGeneration(2)
+		if u.Population() != 0 {
+			t.Errorf("Expected no cells before the edit, got\n%s", u)
+		}
+		h.SeekGeneration(6)
+		if u.Population() != 6 {
+			t.Errorf("Expected the beehive at the generation of the edit, got\n%s", u)
+		}
+
+		h.SeekGeneration(3)
+		h.ToggleCellAt(0, 0)
+		h.SeekGeneration(9)
+		if u.Population() != 0 {
+			t.Errorf("Expected an edit in the past to replace the beehive, got\n%s", u)
+		}
+
+		h.Undo()
+		h.SeekGeneration(9)
+		if u.Population() != 6 {
+			t.Errorf("Expected the beehive back, got\n%s", u)
+		}
+	})
+
+	t.Run("Undo resize", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		h := NewHistory(u, 2)
+		h.SetRectangle(1, 1, Glider().Values())
+		for i := 0; i < 4; i++ {
+			h.Tick()
+		}
+		before := u.String()
+
+		h.Edit(func(u *Universe) { u.Crop() })
+		cropped := u.String()
+		h.ToggleCellAt(0, 0)
+		h.Tick()
+
+		if err := h.SeekGeneration(2); err != errGenerationTooEarly {
+			t.Errorf("Expected error to be %v, got %v", errGenerationTooEarly, err)
+		}
+
+		if !h.Undo() || !h.Undo() || u.Height() != 8 || u.Width() != 8 || u.Generation != 4 || u.String() != before {
+			t.Errorf("Expected the 8x8 universe at generation 4 back, got %d\n%s", u.Generation, u)
+		}
+		if err := h.SeekGeneration(2); err != nil || u.Generation != 2 || u.Population() != 5 {
+			t.Errorf("Expected the frames before the resize back, got %v\n%s", err, u)
+		}
+
+		if !h.Redo() || u.Generation != 4 || u.String() != cropped {
+			t.Errorf("Expected the crop again, got %d\n%s", u.Generation, u)
+		}
+		if h.Undo(); u.String() != before {
+			t.Errorf("Expected the crop to be undone again, got\n%s", u)
+		}
+	})
+
+	t.Run("Before the history", func(t *testing.T) {
+		u := NewUniverse(4, 4)
+		u.Generation = 10
+		h := NewHistory(u, 0)
+
+		if err := h.SeekGeneration(5); err == nil {
+			t.Errorf("Expected an error seeking before the history")
+		}
+	})
+
+	t.Run("Keyframes", func(t *testing.T) {
+		u := NewUniverse(32, 32)
+		u.SetRectangle(1, 1, Glider().Values())
+		h := NewHistory(u, 1)
+		for i := 0; i < 2*keyframeInterval; i++ {
+			h.Tick()
+		}
+
+		keyframes := 0
+		for _, f := range h.frames {
+			if f.deltas == 0 {
+				keyframes++
+			}
+		}
+		if keyframes != 3 {
+			t.Errorf("Expected 3 keyframes, got %d", keyframes)
+		}
+	})
+
+	t.Run("Memory", func(t *testing.T) {
+		u := NewUniverse(64, 64)
+		u.Randomize(40)
+		h := NewHistory(u, 1)
+		h.ToggleCellAt(0, 0)
+		for i := 0; i < 2*maxFrames; i++ {
+			h.Tick()
+		}
+
+		if len(h.frames) != maxFrames || h.Earliest() != maxFrames+1 {
+			t.Errorf("Expected the last %d frames from generation %d, got %d from %d", maxFrames, maxFrames+1, len(h.frames), h.Earliest())
+		}
+
+		// Keyframes shared by delta frames are only counted once.
+		size := 0
+		keyframes := map[*uint8]bool{}
+		for _, f := range h.frames {
+			if frameSize := len(f.patch.Changes) * changeSize; frameSize > len(u.cells) {
+				t.Errorf("Expected a frame to take at most %d bytes, got %d", len(u.cells), frameSize)
+			} else {
+				size += frameSize
+			}
+			if !keyframes[&f.cells[0]] {
+				keyframes[&f.cells[0]] = true
+				size += len(f.cells)
+			}
+		}
+		if limit := (maxFrames + 1) * len(u.cells); size > limit {
+			t.Errorf("Expected the frames to take at most %d bytes, got %d", limit, size)
+		}
+
+		if h.CanUndo() {
+			t.Errorf("Expected the edit to be dropped with its frame")
+		}
+		if h.size() > h.budget {
+			t.Errorf("Expected the history to take at most %d bytes, got %d", h.budget, h.size())
+		}
+		if err := h.SeekGeneration(maxFrames); err != errGenerationTooEarly {
+			t.Errorf("Expected error to be %v, got %v", errGenerationTooEarly, err)
+		}
+		if err := h.SeekGeneration(maxFrames + 100); err != nil || u.Generation != maxFrames+100 {
+			t.Errorf("Expected to seek to generation %d, got %d and %v", maxFrames+100, u.Generation, err)
+		}
+	})
+
+	t.Run("Memory with edits", func(t *testing.T) {
+		u := NewUniverse(64, 64)
+		u.Randomize(40)
+		h := NewHistory(u, 0)
+		h.budget = 160 << 10
+
+		// Seeking back and editing keeps the frames below maxFrames, while
+		// the edits keep the frames they replaced.
+		for round := 0; round < 20; round++ {
+			for i := 0; i < 1000; i++ {
+				h.Tick()
+			}
+			h.SeekGeneration(u.Generation - 500)
+			h.ToggleCellAt(uint32(round), 0)
+
+			if size := h.size(); size > h.budget {
+				t.Fatalf("Expected the history to take at most %d bytes after round %d, got %d", h.budget, round, size)
+			}
+		}
+
+		if len(h.undo) == 0 || len(h.undo) == 20 {
+			t.Errorf("Expected only the last edits to be kept, got %d", len(h.undo))
+		}
+		before := u.String()
+		h.ToggleCellAt(5, 5)
+		if !h.Undo() || u.String() != before {
+			t.Errorf("Expected the last edit to be undone, got\n%s", u)
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// universeJSON is the JSON representation of a universe. The cells are
+// encoded like the body of an RLE pattern, see ParseRLE.
+type universeJSON struct {
+	Height     uint32   `json:"height"`
+	Width      uint32   `json:"width"`
+	Generation uint32   `json:"generation"`
+	Boundary   Boundary `json:"boundary"`
+	Rule       string   `json:"rule,omitempty"`
+	Cells      string   `json:"cells"`
+}
+
+// MarshalText returns the name of the boundary, "dead" or "wrap".
+func (b Boundary) MarshalText() ([]byte, error) {
+	switch b {
+	case BoundaryDead:
+		return []byte("dead"), nil
+	case BoundaryWrap:
+		return []byte("wrap"), nil
+	default:
+		return nil, errInvalidBoundary
+	}
+}
+
+// UnmarshalText parses the name of a boundary, "dead" or "wrap".
+func (b *Boundary) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "dead":
+		*b = BoundaryDead
+	case "wrap":
+		*b = BoundaryWrap
+	default:
+		return errInvalidBoundary
+	}
+
+	return nil
+}
+
+func (u *Universe) toJSON() universeJSON {
+	builder := strings.Builder{}
+	writeRLECells(&builder, u.rows())
+
+	s := universeJSON{
+		Height:     u.height,
+		Width:      u.width,
+		Generation: u.Generation,
+		Boundary:   u.Boundary,
+		Cells:      strings.ReplaceAll(builder.String(), "\n", ""),
+	}
+	if u.rule != nil {
+		s.Rule = u.rule.String()
+	}
+
+	return s
+}
+
+// checkSize returns errInvalidLength if the universe has more than
+// maxDecodedCells cells or maxDecodedRows rows.
+func (s universeJSON) checkSize() error {
+	if s.Height > maxDecodedRows || uint64(s.Height)*uint64(s.Width) > maxDecodedCells {
+		return errInvalidLength
+	}
+
+	return nil
+}
+
+// fromJSON restores the state of the universe, resizing it if needed.
+func (u *Universe) fromJSON(s universeJSON) error {
+	if err := s.checkSize(); err != nil {
+		return err
+	}
+
+	// The decoder stops at the size of the universe, unless it is 0.
+	values, err := parseRLECells(s.Cells, int(s.Height), int(s.Width))
+	if err != nil {
+		return err
+	}
+	if len(values) > int(s.Height) {
+		return errInvalidLength
+	}
+	for _, row := range values {
+		if len(row) > int(s.Width) {
+			return errInvalidLength
+		}
+	}
+
+	var rule *Rule
+	if s.Rule != "" {
+		if rule, err = LookupRule(s.Rule); err != nil {
+			return err
+		}
+	}
+
+	if s.Height != u.height || s.Width != u.width {
+		u.allocate(s.Height, s.Width)
+	}
+	for i := range u.cells {
+		u.cells[i] = Dead
+	}
+	for r, row := range values {
+		copy(u.cells[uint32(r)*u.width:], row)
+	}
+
+	u.Generation = s.Generation
+	u.Boundary = s.Boundary
+	if rule != nil {
+		u.SetRule(rule)
+	}
+	u.changed()
+
+	return nil
+}
+
+// MarshalJSON returns the size, generation, boundary, compiled rule and
+// cells of the universe.
+func (u *Universe) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.toJSON())
+}
+
+// UnmarshalJSON restores a universe encoded by MarshalJSON, resizing it if
+// needed. It can be used on the zero Universe.
+func (u *Universe) UnmarshalJSON(data []byte) error {
+	var s universeJSON
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if u.Rules == nil {
+		u.Rules = u.ConwayRules
+	}
+	return u.fromJSON(s)
+}
+
+// MarshalJSON returns the state of the universe like Universe.MarshalJSON.
+// Neighbor connections are not included.
+func (p *ParallelUniverse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.toJSON())
+}
+
+// UnmarshalJSON restores a universe encoded by MarshalJSON, resizing it if
+// needed. Neighbor connections are left unchanged.
+func (p *ParallelUniverse) UnmarshalJSON(data []byte) error {
+	var s universeJSON
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if err := s.checkSize(); err != nil {
+		return err
+	}
+	if p.Universe == nil {
+		p.attach(NewUniverse(s.Height, s.Width))
+	}
+	if err := p.fromJSON(s); err != nil {
+		return err
+	}
+	if len(p.send.Cells) != len(p.cells) {
+		p.send.Cells = make([]uint8, len(p.cells))
+	}
+
+	return nil
+}
+
+// distributedJSON adds the IDs of a DistributedUniverse and of its
+// neighbors to universeJSON.
+type distributedJSON struct {
+	universeJSON
+
+	ID       string `json:"id"`
+	TopID    string `json:"topId"`
+	BottomID string `json:"bottomId"`
+	LeftID   string `json:"leftId"`
+	RightID  string `json:"rightId"`
+}
+
+// MarshalJSON returns the state of the universe like Universe.MarshalJSON,
+// along with its ID and the IDs of its neighbors.
+func (d *DistributedUniverse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(distributedJSON{
+		universeJSON: d.toJSON(),
+		ID:           d.ID,
+		TopID:        d.TopID,
+		BottomID:     d.BottomID,
+		LeftID:       d.LeftID,
+		RightID:      d.RightID,
+	})
+}
+
+// UnmarshalJSON restores a universe encoded by MarshalJSON, resizing it if
+// needed.
+func (d *DistributedUniverse) UnmarshalJSON(data []byte) error {
+	var s distributedJSON
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if err := s.checkSize(); err != nil {
+		return err
+	}
+	if d.Universe == nil {
+		d.attach(NewUniverse(s.Height, s.Width))
+	}
+	if d.GetNeighbor == nil {
+		d.GetNeighbor = d.getEmptyUniverse
+	}
+	if err := d.fromJSON(s.universeJSON); err != nil {
+		return err
+	}
+
+	d.ID, d.TopID, d.BottomID, d.LeftID, d.RightID = s.ID, s.TopID, s.BottomID, s.LeftID, s.RightID
+	return nil
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestUniverseJSON(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		u := NewUniverse(20, 30)
+		r, _ := LookupRule("seeds")
+		u.SetRule(r)
+		u.Boundary = BoundaryWrap
+		u.Randomize(30)
+		u.Tick()
+
+		data, err := json.Marshal(u)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var u2 Universe
+		if err := json.Unmarshal(data, &u2); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if u2.String() != u.String() || u2.Generation != 1 || u2.Boundary != BoundaryWrap {
+			t.Errorf("Expected the universe to be restored, got generation %d, boundary %d:\n%s", u2.Generation, u2.Boundary, &u2)
+		}
+		if u2.Rule() == nil || u2.Rule().String() != "B2/S" {
+			t.Errorf("Expected rule to be B2/S, got %v", u2.Rule())
+		}
+
+		u.Tick()
+		u2.Tick()
+		if u2.String() != u.String() {
+			t.Errorf("Expected the universes to evolve the same")
+		}
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		u := NewUniverse(4, 4)
+		u.SetRectangle(1, 0, [][]uint8{{Alive, Alive, Alive}})
+
+		data, _ := json.Marshal(u)
+		expected := `{"height":4,"width":4,"generation":0,"boundary":"dead","cells":"$3o!"}`
+		if string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for input, expected := range map[string]string{
+			`{"height":2,"width":2,"boundary":"dead","cells":"3o!"}`:  errInvalidLength.Error(),
+			`{"height":2,"width":2,"boundary":"dead","cells":"2z!"}`:  errInvalidCharacter.Error(),
+			`{"height":2,"width":2,"boundary":"round","cells":"o!"}`:  errInvalidBoundary.Error(),
+			`{"height":2,"width":2,"rule":"B9/S","cells":"o!"}`:       errInvalidRule.Error(),
+			`{"height":2,"width":2,"boundary":"dead","cells":"o$o!"}`: "",
+			`{"height":65536,"width":65536,"cells":"o!"}`:             errInvalidLength.Error(),
+			`{"height":50000,"width":50000,"cells":"o!"}`:             errInvalidLength.Error(),
+			`{"height":2,"width":2,"cells":"300000000o!"}`:            errInvalidLength.Error(),
+			`{"height":2,"width":2,"cells":"o$o$o!"}`:                 errInvalidLength.Error(),
+			`{"height":0,"width":0,"cells":"o!"}`:                     errInvalidLength.Error(),
+		} {
+			var u Universe
+			err := json.Unmarshal([]byte(input), &u)
+			if (expected == "" && err != nil) || (expected != "" && (err == nil || !strings.Contains(err.Error(), expected))) {
+				t.Errorf("Expected %s to return %q, got %v", input, expected, err)
+			}
+		}
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		input := []byte(`{"height":65536,"width":65536,"cells":"o!"}`)
+		if err := json.Unmarshal(input, new(ParallelUniverse)); err == nil || !strings.Contains(err.Error(), errInvalidLength.Error()) {
+			t.Errorf("Expected error to be %v, got %v", errInvalidLength, err)
+		}
+		if err := json.Unmarshal(input, new(DistributedUniverse)); err == nil || !strings.Contains(err.Error(), errInvalidLength.Error()) {
+			t.Errorf("Expected error to be %v, got %v", errInvalidLength, err)
+		}
+	})
+}
+
+func TestParallelUniverseJSON(t *testing.T) {
+	p := NewParallelUniverse(16, 16)
+	p.Randomize(40)
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var p2 ParallelUniverse
+	if err := json.Unmarshal(data, &p2); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if p2.String() != p.String() || len(p2.send.Cells) != 256 {
+		t.Errorf("Expected the universe to be restored, got:\n%s", p2.String())
+	}
+
+	if p2.outside == nil || p2.Rules == nil {
+		t.Errorf("Expected the neighbor hooks to be set")
+	}
+}
+
+func TestDistributedUniverseJSON(t *testing.T) {
+	d := NewDistributedUniverse(GenerateKey(), 16, 16)
+	d.SetLeftNeighbor(GenerateKey())
+	d.Randomize(40)
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(data), `"leftId":"`+d.LeftID+`"`) {
+		t.Errorf("Expected the neighbor IDs, got %s", data)
+	}
+
+	var d2 DistributedUniverse
+	if err := json.Unmarshal(data, &d2); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if d2.String() != d.String() || d2.ID != d.ID || d2.LeftID != d.LeftID || d2.GetNeighbor == nil {
+		t.Errorf("Expected the universe to be restored, got %s, %s:\n%s", d2.ID, d2.LeftID, d2.String())
+	}
+}
@@ -0,0 +1,194 @@
+package game
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	life105Header = "#Life 1.05"
+	life106Header = "#Life 1.06"
+)
+
+// ParseLife105 reads a pattern in the Life 1.05 format: "#D" description
+// lines, an optional "#N" or "#R" rule line in S/B notation, where "#N"
+// followed by text is the name of the pattern instead, and blocks of
+// "*" and "." cells, each placed by a "#P" line with the coordinates of its
+// top-left corner. The figure is anchored at the origin of the coordinates
+// when it lies within the pattern, and at its center otherwise.
+// See https://conwaylife.com/wiki/Life_1.05 for more information.
+func ParseLife105(data string) (*Pattern, error) {
+	p := &Pattern{}
+	cells := map[[2]int]uint8{}
+	x, y := 0, 0
+	row := 0
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case i == 0 && strings.HasPrefix(line, life105Header):
+			continue
+		case strings.HasPrefix(line, "#P"):
+			var err error
+			if x, y, err = parseCoordinates(line[2:]); err != nil {
+				return nil, err
+			}
+			row = 0
+		case strings.HasPrefix(line, "#R"):
+			p.Rule = canonicalRule(strings.TrimSpace(line[2:]))
+		case strings.HasPrefix(line, "#N"):
+			if name := strings.TrimSpace(line[2:]); name != "" {
+				p.Name = name
+			} else {
+				p.Rule = canonicalRule("23/3")
+			}
+		case strings.HasPrefix(line, "#D"), strings.HasPrefix(line, "#C"):
+			p.Comments = append(p.Comments, strings.TrimSpace(line[2:]))
+		case strings.HasPrefix(line, "#"):
+			continue
+		default:
+			for column, char := range line {
+				switch char {
+				case '*', 'O':
+					cells[[2]int{y + row, x + column}] = Alive
+				case '.':
+				default:
+					return nil, errInvalidCharacter
+				}
+			}
+			row++
+		}
+	}
+
+	p.Figure = figureFromCoordinates(cells)
+	return p, nil
+}
+
+// ParseLife106 reads a pattern in the Life 1.06 format: one "x y" pair of
+// coordinates per live cell. The figure is anchored like in ParseLife105.
+// See https://conwaylife.com/wiki/Life_1.06 for more information.
+func ParseLife106(data string) (*Pattern, error) {
+	p := &Pattern{}
+	cells := map[[2]int]uint8{}
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case i == 0 && strings.HasPrefix(line, life106Header):
+			continue
+		case strings.HasPrefix(line, "#"):
+			p.Comments = append(p.Comments, strings.TrimSpace(line[1:]))
+		default:
+			x, y, err := parseCoordinates(line)
+			if err != nil {
+				return nil, err
+			}
+			cells[[2]int{y, x}] = Alive
+		}
+	}
+
+	p.Figure = figureFromCoordinates(cells)
+	return p, nil
+}
+
+func parseCoordinates(s string) (x, y int, err error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return 0, 0, errInvalidCharacter
+	}
+
+	if x, err = strconv.Atoi(fields[0]); err != nil {
+		return 0, 0, errInvalidCharacter
+	}
+	if y, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, errInvalidCharacter
+	}
+
+	return x, y, nil
+}
+
+// canonicalRule returns the rulestring in B/S notation, or unchanged if it
+// cannot be parsed.
+func canonicalRule(rulestring string) string {
+	r, err := ParseRule(rulestring)
+	if err != nil {
+		return rulestring
+	}
+
+	return r.String()
+}
+
+// figureFromCoordinates returns a figure with the cells, keyed by row and
+// column, anchored at the origin if it lies within them.
+func figureFromCoordinates(cells map[[2]int]uint8) *Figure {
+	if len(cells) == 0 {
+		return NewFigure(nil)
+	}
+
+	top, left := math.MaxInt, math.MaxInt
+	bottom, right := math.MinInt, math.MinInt
+	for position := range cells {
+		top, left = min(top, position[0]), min(left, position[1])
+		bottom, right = max(bottom, position[0]), max(right, position[1])
+	}
+
+	values := make([][]uint8, bottom-top+1)
+	for r := range values {
+		values[r] = make([]uint8, right-left+1)
+	}
+	for position, cell := range cells {
+		values[position[0]-top][position[1]-left] = cell
+	}
+
+	f := NewFigure(values)
+	if top <= 0 && bottom >= 0 && left <= 0 && right >= 0 {
+		f.deltaX, f.deltaY = -top, -left
+	}
+
+	return f
+}
+
+// Life105 returns the pattern in the Life 1.05 format, as a single block
+// with the anchor of the figure at the origin. Rules that cannot be written
+// in S/B notation are left out.
+func (p *Pattern) Life105() string {
+	builder := strings.Builder{}
+	builder.WriteString(life105Header + "\n")
+	if p.Name != "" {
+		builder.WriteString("#N " + p.Name + "\n")
+	}
+	for _, comment := range p.Comments {
+		builder.WriteString("#D " + comment + "\n")
+	}
+
+	if rule := canonicalRule(p.Rule); rule == "" || rule == "B3/S23" {
+		builder.WriteString("#N\n")
+	} else if birth, survival, ok := strings.Cut(rule, "/S"); ok && strings.HasPrefix(birth, "B") &&
+		!strings.ContainsAny(rule, "abcdefghijklmnopqrstuvwxyz-") {
+		builder.WriteString("#R " + survival + "/" + birth[1:] + "\n")
+	}
+
+	builder.WriteString("#P " + strconv.Itoa(-p.deltaY) + " " + strconv.Itoa(-p.deltaX) + "\n")
+	builder.WriteString(strings.ReplaceAll(p.Figure.String(), "O", "*"))
+
+	return builder.String()
+}
+
+// Life106 returns the pattern in the Life 1.06 format, with the live cells
+// positioned relative to the anchor of the figure.
+func (p *Pattern) Life106() string {
+	builder := strings.Builder{}
+	builder.WriteString(life106Header + "\n")
+	for r, row := range p.values {
+		for c, cell := range row {
+			if cell != Dead {
+				builder.WriteString(strconv.Itoa(c-p.deltaY) + " " + strconv.Itoa(r-p.deltaX) + "\n")
+			}
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestParseLife105(t *testing.T) {
+	t.Run("Blocks", func(t *testing.T) {
+		p, err := ParseLife105("#Life 1.05\n#D Two blocks\n#R 23/36\n#P -1 -1\n**\n**\n#P 3 -1\n**\n**\n")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := "OO..OO\nOO..OO\n"
+		if p.String() != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, p)
+		}
+		if p.Rule != "B36/S23" {
+			t.Errorf("Expected rule to be B36/S23, got %q", p.Rule)
+		}
+		if p.DeltaX() != 1 || p.DeltaY() != 1 {
+			t.Errorf("Expected the anchor at the origin, got (%d, %d)", p.DeltaX(), p.DeltaY())
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		p := &Pattern{Figure: Glider(), Comments: []string{"Glider"}, Rule: "B36/S23"}
+		p2, err := ParseLife105(p.Life105())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if p2.Life105() != p.Life105() {
+			t.Errorf("Expected:\n%s\ngot:\n%s", p.Life105(), p2.Life105())
+		}
+	})
+
+	t.Run("Round trip name", func(t *testing.T) {
+		for _, rule := range []string{"", "B3/S23", "B36/S23"} {
+			p := &Pattern{Figure: Glider(), Name: "Glider", Comments: []string{"The smallest spaceship"}, Rule: rule}
+			p2, err := ParseLife105(p.Life105())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if p2.Name != "Glider" || len(p2.Comments) != 1 || p2.Comments[0] != "The smallest spaceship" {
+				t.Errorf("Expected the name and the comment back, got %q and %q", p2.Name, p2.Comments)
+			}
+			if p2.Life105() != p.Life105() {
+				t.Errorf("Expected:\n%s\ngot:\n%s", p.Life105(), p2.Life105())
+			}
+		}
+	})
+}
+
+func TestParseLife106(t *testing.T) {
+	t.Run("Coordinates", func(t *testing.T) {
+		p, err := ParseLife106("#Life 1.06\n0 -1\n1 0\n-1 1\n0 1\n1 1\n")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if p.String() != Glider().String() {
+			t.Errorf("Expected the glider, got:\n%s", p)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := ParseLife106("#Life 1.06\n0 a\n"); err != errInvalidCharacter {
+			t.Errorf("Expected error to be %v, got %v", errInvalidCharacter, err)
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		p := NewPattern(Pulsar())
+		p2, err := ParseLife106(p.Life106())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if p2.Life106() != p.Life106() {
+			t.Errorf("Expected:\n%s\ngot:\n%s", p.Life106(), p2.Life106())
+		}
+	})
+}
@@ -0,0 +1,296 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	macrocellHeader = "[M2]"
+
+	// macrocellLeafLevel is the level of the 8x8 leaves of two-state
+	// patterns. Multi-state patterns use level 1 nodes of four states.
+	macrocellLeafLevel = 3
+)
+
+// macrocellNode is a node of a macrocell quadtree, covering a square of
+// 2^level cells. Leaves hold their cells, and other nodes the indexes of
+// their north-west, north-east, south-west and south-east children, where
+// 0 is an empty square.
+type macrocellNode struct {
+	level    int
+	children [4]int
+	cells    [][]uint8
+}
+
+// ParseMacrocell reads a pattern in Golly's macrocell format into a sparse
+// universe, with the rule given by its "#R" line and the generation given
+// by its "#G" line. The root square of the pattern is centered on the
+// origin. See https://conwaylife.com/wiki/Macrocell for more information.
+func ParseMacrocell(data string) (*SparseUniverse, error) {
+	lines := strings.Split(data, "\n")
+	if !strings.HasPrefix(strings.TrimSpace(lines[0]), macrocellHeader) {
+		return nil, errInvalidHeader
+	}
+
+	var rule *Rule
+	var generation uint64
+	nodes := []macrocellNode{{}}
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#R"):
+			var err error
+			if rule, err = LookupRule(strings.TrimSpace(line[2:])); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "#G"):
+			var err error
+			if generation, err = strconv.ParseUint(strings.TrimSpace(line[2:]), 10, 32); err != nil {
+				return nil, errInvalidHeader
+			}
+		case strings.HasPrefix(line, "#"):
+			continue
+		case line[0] >= '1' && line[0] <= '9':
+			node, err := parseMacrocellNode(line, nodes)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		default:
+			leaf, err := parseMacrocellLeaf(line)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, leaf)
+		}
+	}
+
+	s, err := NewSparseUniverse(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Generation = uint32(generation)
+	if root := len(nodes) - 1; root > 0 {
+		offset := -(int64(1) << (nodes[root].level - 1))
+		s.setMacrocellNode(nodes, root, offset, offset)
+	}
+
+	return s, nil
+}
+
+func parseMacrocellNode(line string, nodes []macrocellNode) (macrocellNode, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 5 {
+		return macrocellNode{}, errInvalidNode
+	}
+
+	level, err := strconv.Atoi(fields[0])
+	if err != nil || level < 1 || level > 63 {
+		return macrocellNode{}, errInvalidNode
+	}
+
+	node := macrocellNode{level: level}
+	if level == 1 {
+		node.cells = [][]uint8{make([]uint8, 2), make([]uint8, 2)}
+	}
+	for i, field := range fields[1:] {
+		value, err := strconv.Atoi(field)
+		if err != nil || value < 0 {
+			return macrocellNode{}, errInvalidNode
+		}
+
+		if level == 1 {
+			// The children of level 1 nodes are the states of its cells.
+			if value > 255 {
+				return macrocellNode{}, errInvalidNode
+			}
+			node.cells[i/2][i%2] = uint8(value)
+			continue
+		}
+		if value >= len(nodes) || (value > 0 && nodes[value].level != level-1) {
+			return macrocellNode{}, errInvalidNode
+		}
+		node.children[i] = value
+	}
+
+	return node, nil
+}
+
+func parseMacrocellLeaf(line string) (macrocellNode, error) {
+	node := macrocellNode{level: macrocellLeafLevel, cells: make([][]uint8, 8)}
+	for r := range node.cells {
+		node.cells[r] = make([]uint8, 8)
+	}
+
+	row, column := 0, 0
+	for _, char := range line {
+		switch char {
+		case '$':
+			row, column = row+1, 0
+			continue
+		case '*':
+			if row >= 8 || column >= 8 {
+				return macrocellNode{}, errInvalidNode
+			}
+			node.cells[row][column] = Alive
+		case '.':
+		default:
+			return macrocellNode{}, errInvalidCharacter
+		}
+		column++
+	}
+
+	return node, nil
+}
+
+// setMacrocellNode sets the live cells of a node with its top-left corner
+// at the given position.
+func (s *SparseUniverse) setMacrocellNode(nodes []macrocellNode, index int, row, column int64) {
+	node := nodes[index]
+	if node.cells != nil {
+		s.SetFigure(row, column, &Figure{values: node.cells})
+		return
+	}
+
+	half := int64(1) << (node.level - 1)
+	for i, child := range node.children {
+		if child != 0 {
+			s.setMacrocellNode(nodes, child, row+int64(i/2)*half, column+int64(i%2)*half)
+		}
+	}
+}
+
+// Macrocell returns the universe in Golly's macrocell format. Identical
+// squares of the quadtree are written once, so that large repetitive
+// patterns stay small.
+func (s *SparseUniverse) Macrocell() string {
+	builder := strings.Builder{}
+	builder.WriteString(macrocellHeader + " (vita)\n")
+	builder.WriteString("#R " + s.rule.String() + "\n")
+	if s.Generation > 0 {
+		builder.WriteString("#G " + strconv.FormatUint(uint64(s.Generation), 10) + "\n")
+	}
+
+	positions := s.Cells()
+	if len(positions) == 0 {
+		return builder.String()
+	}
+
+	w := macrocellWriter{cells: s.cells, ids: map[string]int{}, leafLevel: macrocellLeafLevel}
+	for _, cell := range s.cells {
+		if cell > Alive {
+			w.leafLevel = 1
+		}
+	}
+
+	// The root is the smallest square centered on the origin that contains
+	// all cells, and is never a leaf.
+	row, column, height, width, _ := s.Bounds()
+	level := w.leafLevel + 1
+	for level < 63 {
+		half := int64(1) << (level - 1)
+		if row >= -half && column >= -half && row+height <= half && column+width <= half {
+			break
+		}
+		level++
+	}
+
+	offset := -(int64(1) << (level - 1))
+	w.write(level, offset, offset, positions)
+	for _, line := range w.lines {
+		builder.WriteString(line + "\n")
+	}
+
+	return builder.String()
+}
+
+// macrocellWriter hash-conses the nodes of a macrocell quadtree.
+type macrocellWriter struct {
+	cells     map[Position]uint8
+	leafLevel int
+	lines     []string
+	ids       map[string]int
+}
+
+// write adds the node of the given level with its top-left corner at the
+// given position, containing the given live cells, and returns its index.
+func (w *macrocellWriter) write(level int, row, column int64, positions []Position) int {
+	if len(positions) == 0 {
+		return 0
+	}
+
+	var line string
+	switch {
+	case level == w.leafLevel && level == 1:
+		states := [4]uint8{}
+		for _, p := range positions {
+			states[(p.Row-row)*2+p.Column-column] = w.cells[p]
+		}
+		line = "1"
+		for _, state := range states {
+			line += " " + strconv.Itoa(int(state))
+		}
+	case level == w.leafLevel:
+		line = macrocellLeaf(row, column, positions)
+	default:
+		half := int64(1) << (level - 1)
+		quadrants := [4][]Position{}
+		for _, p := range positions {
+			i := 0
+			if p.Row >= row+half {
+				i += 2
+			}
+			if p.Column >= column+half {
+				i++
+			}
+			quadrants[i] = append(quadrants[i], p)
+		}
+
+		line = strconv.Itoa(level)
+		for i, quadrant := range quadrants {
+			child := w.write(level-1, row+int64(i/2)*half, column+int64(i%2)*half, quadrant)
+			line += " " + strconv.Itoa(child)
+		}
+	}
+
+	if id, ok := w.ids[line]; ok {
+		return id
+	}
+
+	w.lines = append(w.lines, line)
+	w.ids[line] = len(w.lines)
+	return len(w.lines)
+}
+
+// macrocellLeaf returns the line of an 8x8 leaf: rows of "*" and "." ending
+// with "$", without trailing dead cells or empty rows.
+func macrocellLeaf(row, column int64, positions []Position) string {
+	var rows [8][8]bool
+	last := 0
+	for _, p := range positions {
+		rows[p.Row-row][p.Column-column] = true
+		last = max(last, int(p.Row-row))
+	}
+
+	builder := strings.Builder{}
+	for r := 0; r <= last; r++ {
+		end := 8
+		for end > 0 && !rows[r][end-1] {
+			end--
+		}
+		for c := 0; c < end; c++ {
+			if rows[r][c] {
+				builder.WriteByte('*')
+			} else {
+				builder.WriteByte('.')
+			}
+		}
+		builder.WriteByte('$')
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMacrocell(t *testing.T) {
+	t.Run("Glider", func(t *testing.T) {
+		s, err := ParseMacrocell("[M2] (golly 4.2)\n#R B3/S23\n#G 12\n$$..*$...*$.***$\n4 0 0 0 1\n")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		f, row, column := s.Figure()
+		if f.String() != Glider().String() || row != 2 || column != 1 {
+			t.Errorf("Expected the glider at (2, 1), got (%d, %d):\n%s", row, column, f)
+		}
+		if s.Generation != 12 {
+			t.Errorf("Expected generation 12, got %d", s.Generation)
+		}
+	})
+
+	t.Run("Multi-state", func(t *testing.T) {
+		s, err := ParseMacrocell("[M2] (golly 4.2)\n#R B3/S23\n1 0 2 3 0\n2 0 0 1 0\n")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if s.Cell(0, -1) != 2 || s.Cell(1, -2) != 3 || s.Population() != 2 {
+			t.Errorf("Expected two cells, got %v", s.Cells())
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for input, expected := range map[string]error{
+			"#R B3/S23\n$*$\n":       errInvalidHeader,
+			"[M2]\n$*x$\n":           errInvalidCharacter,
+			"[M2]\n$*$\n5 0 0 0 1\n": errInvalidNode,
+			"[M2]\n$*$\n4 0 0 0 2\n": errInvalidNode,
+			"[M2]\n#R B3/S2x\n$*$\n": errInvalidRule,
+		} {
+			if _, err := ParseMacrocell(input); err != expected {
+				t.Errorf("Expected %q to return %v, got %v", input, expected, err)
+			}
+		}
+	})
+}
+
+func TestMacrocell(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		u := NewUniverse(40, 50)
+		u.Randomize(40)
+		s, _ := u.Sparse()
+		s.Generation = 7
+
+		s2, err := ParseMacrocell(s.Macrocell())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		f, row, column := s.Figure()
+		f2, row2, column2 := s2.Figure()
+		if f.String() != f2.String() || row != row2 || column != column2 || s2.Generation != 7 {
+			t.Errorf("Expected:\n%s\ngot:\n%s", s.Macrocell(), s2.Macrocell())
+		}
+	})
+
+	t.Run("Shared nodes", func(t *testing.T) {
+		s, _ := NewSparseUniverse(nil)
+		for i := int64(0); i < 4; i++ {
+			s.SetFigure(i<<40, -i<<40, Glider())
+		}
+
+		mc := s.Macrocell()
+		if lines := strings.Count(mc, "\n"); lines > 200 {
+			t.Errorf("Expected repeated gliders to share nodes, got %d lines", lines)
+		}
+
+		s2, err := ParseMacrocell(mc)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if s2.Population() != 20 || s2.Cell(3<<40+2, -3<<40+2) != Alive {
+			t.Errorf("Expected four gliders, got %v", s2.Cells())
+		}
+	})
+
+	t.Run("Multi-state", func(t *testing.T) {
+		s, _ := NewSparseUniverse(nil)
+		s.SetCell(5, -3, 2)
+		s.SetCell(-9, 4, 200)
+
+		s2, err := ParseMacrocell(s.Macrocell())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if s2.Cell(5, -3) != 2 || s2.Cell(-9, 4) != 200 || s2.Population() != 2 {
+			t.Errorf("Expected both cells, got %v", s2.Cells())
+		}
+	})
+}
@@ -33,15 +33,21 @@ type ParallelUniverse struct {
 }
 
 func NewParallelUniverse(height, width uint32) *ParallelUniverse {
-	p := &ParallelUniverse{
-		Universe: NewUniverse(height, width),
-		send: NeighborData{
-			Generation: 0,
-			Cells:      make([]uint8, height*width),
-		},
+	p := &ParallelUniverse{}
+	p.attach(NewUniverse(height, width))
+	return p
+}
+
+// attach makes u the grid of the universe, reading the cells beyond its
+// edges from the neighbors.
+func (p *ParallelUniverse) attach(u *Universe) {
+	p.Universe = u
+	p.send = NeighborData{
+		Generation: 0,
+		Cells:      make([]uint8, len(u.cells)),
 	}
 	p.Rules = p.rules
-	return p
+	p.outside = p.neighborCell
 }
 
 func (p *ParallelUniverse) rules(cell uint8, row, column uint32) uint8 {
@@ -56,64 +62,12 @@ func (p *ParallelUniverse) Neighbors(row, column uint32) uint8 {
 	r, c := int32(row), int32(column)
 	for _, neighborRow := range []int32{r - 1, r, r + 1} {
 		for _, neighborColumn := range []int32{c - 1, c, c + 1} {
-			switch {
-			case neighborRow == r && neighborColumn == c:
-				// Skip checking the cell itself
+			// Skip checking the cell itself
+			if neighborRow == r && neighborColumn == c {
 				continue
-			case neighborRow < 0 && neighborColumn < 0:
-				// TODO: check the universe above and to the left for 1 pixel?
-			case neighborRow < 0 && neighborColumn >= int32(p.width):
-				// TODO: check the universe above and to the right for 1 pixel?
-			case neighborRow >= int32(p.height) && neighborColumn < 0:
-				// TODO: check the universe below and to the left for 1 pixel?
-			case neighborRow >= int32(p.height) && neighborColumn >= int32(p.width):
-				// check the universe below and to the right for one pixel?
-			case neighborRow < 0:
-				// check the universe above
-				if p.TopNeighbor == nil {
-					continue
-				}
-
-				neighborIdx := p.GetIndex(p.height-1, uint32(neighborColumn))
-				if p.TopNeighbor.Data.Cells[neighborIdx] != Dead {
-					count++
-				}
-			case neighborRow >= int32(p.height):
-				// check the universe below
-				if p.BottomNeighbor == nil {
-					continue
-				}
-
-				neighborIdx := p.GetIndex(0, uint32(neighborColumn))
-				if p.BottomNeighbor.Data.Cells[neighborIdx] != Dead {
-					count++
-				}
-			case neighborColumn < 0:
-				// check the universe to the left
-				if p.LeftNeighbor == nil {
-					continue
-				}
-
-				neighborIdx := p.GetIndex(uint32(neighborRow), p.width-1)
-				if p.LeftNeighbor.Data.Cells[neighborIdx] != Dead {
-					count++
-				}
-			case neighborColumn >= int32(p.width):
-				// check the universe to the right
-				if p.RightNeighbor == nil {
-					continue
-				}
-
-				neighborIdx := p.GetIndex(uint32(neighborRow), 0)
-				if p.RightNeighbor.Data.Cells[neighborIdx] != Dead {
-					count++
-				}
-			default:
-				// check the current universe
-				neighborIdx := p.GetIndex(uint32(neighborRow), uint32(neighborColumn))
-				if p.Cell(neighborIdx) != Dead {
-					count++
-				}
+			}
+			if p.cellAt(neighborRow, neighborColumn) != Dead {
+				count++
 			}
 		}
 	}
@@ -121,12 +75,107 @@ func (p *ParallelUniverse) Neighbors(row, column uint32) uint8 {
 	return count
 }
 
+// neighborCell returns the state of a cell just beyond the edges of the
+// universe, as last received from the adjoining neighbor Universe.
+func (p *ParallelUniverse) neighborCell(row, column int32) uint8 {
+	switch {
+	case row < 0 && column < 0:
+		// TODO: check the universe above and to the left for 1 pixel?
+	case row < 0 && column >= int32(p.width):
+		// TODO: check the universe above and to the right for 1 pixel?
+	case row >= int32(p.height) && column < 0:
+		// TODO: check the universe below and to the left for 1 pixel?
+	case row >= int32(p.height) && column >= int32(p.width):
+		// check the universe below and to the right for one pixel?
+	case row < 0:
+		// check the universe above
+		if p.TopNeighbor != nil {
+			return p.TopNeighbor.Data.Cells[p.GetIndex(p.height-1, uint32(column))]
+		}
+	case row >= int32(p.height):
+		// check the universe below
+		if p.BottomNeighbor != nil {
+			return p.BottomNeighbor.Data.Cells[p.GetIndex(0, uint32(column))]
+		}
+	case column < 0:
+		// check the universe to the left
+		if p.LeftNeighbor != nil {
+			return p.LeftNeighbor.Data.Cells[p.GetIndex(uint32(row), p.width-1)]
+		}
+	case column >= int32(p.width):
+		// check the universe to the right
+		if p.RightNeighbor != nil {
+			return p.RightNeighbor.Data.Cells[p.GetIndex(uint32(row), 0)]
+		}
+	}
+
+	return Dead
+}
+
 func (p *ParallelUniverse) MultiTick() {
 	p.SendDataToNeighbors()
 	p.WaitForNeighborsData()
 	p.Tick()
 }
 
+// ParallelGroup is a set of connected parallel universes that advance
+// together, so that a Runner can run them as a single simulation.
+type ParallelGroup []*ParallelUniverse
+
+// Tick advances every universe of the group by one generation, exchanging
+// their edges with their neighbors.
+func (g ParallelGroup) Tick() {
+	var wg sync.WaitGroup
+	for _, p := range g {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.MultiTick()
+		}()
+	}
+	wg.Wait()
+}
+
+// Stats returns the counts of the whole group, at the generation of its
+// first universe.
+func (g ParallelGroup) Stats() Stats {
+	var stats Stats
+	for i, p := range g {
+		s := p.Stats()
+		if i == 0 {
+			stats.Generation = s.Generation
+		}
+		stats.Population += s.Population
+		stats.Births += s.Births
+		stats.Deaths += s.Deaths
+	}
+
+	return stats
+}
+
+// Stable reports whether every universe of the group is stable.
+func (g ParallelGroup) Stable() bool {
+	for _, p := range g {
+		if !p.Stable() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Hash combines the hashes of the universes of the group.
+func (g ParallelGroup) Hash() uint64 {
+	const prime = 1099511628211
+
+	var hash uint64
+	for _, p := range g {
+		hash = hash*prime ^ p.Hash()
+	}
+
+	return hash
+}
+
 // WaitForNeighborsData waits for all neighbors to send their data.
 func (p *ParallelUniverse) WaitForNeighborsData() {
 	var wg sync.WaitGroup
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"strings"
+)
+
+// Pattern is a Figure read from a pattern file, along with the metadata
+// that the file format can carry.
+type Pattern struct {
+	*Figure
+
+	Name     string
+	Author   string
+	Comments []string
+	// Rule is the rulestring given by the file, or empty if there is none.
+	Rule string
+}
+
+// NewPattern returns a Pattern without metadata for the figure.
+func NewPattern(f *Figure) *Pattern {
+	return &Pattern{Figure: f}
+}
+
+// ParsePattern reads a pattern in any of the supported formats, detected
+// from its first line: Life 1.05 and Life 1.06 by their headers, plaintext
+// by its "!" comments or rows of "O" and ".", and RLE otherwise.
+func ParsePattern(data string) (*Pattern, error) {
+	first := strings.TrimSpace(data)
+	if i := strings.IndexByte(first, '\n'); i >= 0 {
+		first = strings.TrimSpace(first[:i])
+	}
+
+	switch {
+	case strings.HasPrefix(first, life105Header):
+		return ParseLife105(data)
+	case strings.HasPrefix(first, life106Header):
+		return ParseLife106(data)
+	case strings.HasPrefix(first, "!"), first != "" && strings.Trim(first, "O*.") == "":
+		return ParseCells(data)
+	default:
+		return ParseRLE(data)
+	}
+}
+
+// ParsePattern replaces the cells of the universe with a pattern in any of
+// the formats supported by ParsePattern, placed in the top-left corner. If
+// the pattern has a rule, it is compiled and set on the universe.
+func (u *Universe) ParsePattern(data string) error {
+	p, err := ParsePattern(data)
+	if err != nil {
+		return err
+	}
+
+	return u.setPattern(p)
+}
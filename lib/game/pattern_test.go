@@ -0,0 +1,36 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestParsePattern(t *testing.T) {
+	glider := NewPattern(Glider())
+	for name, data := range map[string]string{
+		"RLE":       glider.RLE(),
+		"Plaintext": glider.Cells(),
+		"Life 1.05": glider.Life105(),
+		"Life 1.06": glider.Life106(),
+	} {
+		p, err := ParsePattern(data)
+		if err != nil {
+			t.Errorf("Expected %s to parse, got %v", name, err)
+			continue
+		}
+		if p.String() != glider.String() {
+			t.Errorf("Expected %s to be the glider, got:\n%s", name, p)
+		}
+	}
+
+	t.Run("Universe", func(t *testing.T) {
+		u := NewUniverse(4, 4)
+		if err := u.ParsePattern("!Name: Blinker\nOOO\n"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := "OOO.\n....\n....\n....\n"
+		if u.String() != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, u)
+		}
+	})
+}
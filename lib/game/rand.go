@@ -1,15 +1,284 @@
 package game
 
 import (
-	"crypto/rand"
+	crand "crypto/rand"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"sort"
 )
 
-func randomNumber() int {
-	var b [1]byte
-	rand.Read(b[:])
-	val := int(b[0]) >> 1
-	if val > 100 {
-		val = val >> 1
+// defaultNoiseScale is the size of the clusters of NoisePerlin when
+// RandomOptions does not set one.
+const defaultNoiseScale = 8
+
+// Symmetry is the symmetry of the random cells of a region, as in the soups
+// searched by apgsearch. Symmetric soups are more likely to produce rare
+// oscillators and spaceships.
+// See https://conwaylife.com/wiki/Symmetry for more information.
+type Symmetry uint8
+
+const (
+	// SymmetryNone fills every cell independently, like C1 soups.
+	SymmetryNone Symmetry = iota
+	// SymmetryC2 keeps the cells the same when rotated by 180 degrees.
+	SymmetryC2
+	// SymmetryC4 keeps the cells the same when rotated by 90 degrees.
+	SymmetryC4
+	// SymmetryD8 keeps the cells the same when rotated by 90 degrees and
+	// mirrored along either axis or diagonal.
+	SymmetryD8
+)
+
+var symmetryNames = []string{"C1", "C2", "C4", "D8"}
+
+// MarshalText returns the name of the symmetry, "C1", "C2", "C4" or "D8".
+func (s Symmetry) MarshalText() ([]byte, error) {
+	if int(s) >= len(symmetryNames) {
+		return nil, errInvalidSymmetry
+	}
+
+	return []byte(symmetryNames[s]), nil
+}
+
+// UnmarshalText parses the name of a symmetry, "C1", "C2", "C4" or "D8".
+func (s *Symmetry) UnmarshalText(text []byte) error {
+	for i, name := range symmetryNames {
+		if string(text) == name {
+			*s = Symmetry(i)
+			return nil
+		}
+	}
+
+	return errInvalidSymmetry
+}
+
+// Noise is how the live cells of a random region are distributed.
+type Noise uint8
+
+const (
+	// NoiseUniform makes every cell equally likely to be alive.
+	NoiseUniform Noise = iota
+	// NoisePerlin groups the live cells into smooth clusters, using
+	// Perlin's gradient noise.
+	NoisePerlin
+)
+
+// MarshalText returns the name of the noise, "uniform" or "perlin".
+func (n Noise) MarshalText() ([]byte, error) {
+	switch n {
+	case NoiseUniform:
+		return []byte("uniform"), nil
+	case NoisePerlin:
+		return []byte("perlin"), nil
+	default:
+		return nil, errInvalidNoise
+	}
+}
+
+// UnmarshalText parses the name of a noise, "uniform" or "perlin".
+func (n *Noise) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "uniform":
+		*n = NoiseUniform
+	case "perlin":
+		*n = NoisePerlin
+	default:
+		return errInvalidNoise
+	}
+
+	return nil
+}
+
+// RandomOptions configures how RandomizeWith fills a universe.
+type RandomOptions struct {
+	// Rand is the source of the random cells, so that sources with the
+	// same seed fill the same cells. A nil Rand uses a random seed.
+	Rand *rand.Rand
+	// Density is the percentage of live cells in the region, from 0 to 100.
+	Density int
+	// Region is the rectangle of cells to fill, leaving the others as they
+	// are. An empty region fills the whole universe.
+	Region Rect
+	// Symmetry of the cells within the region. SymmetryC4 and SymmetryD8
+	// need a square region.
+	Symmetry Symmetry
+	Noise    Noise
+	// Scale is the approximate size in cells of the clusters of NoisePerlin.
+	Scale int
+}
+
+// Randomize fills the universe with random cells, so that the given
+// percentage of them are alive.
+func (u *Universe) Randomize(livePopulation int) {
+	u.RandomizeWith(RandomOptions{Density: min(max(livePopulation, 0), 100)})
+}
+
+// RandomizeWith fills a region of the universe with random cells. Exactly
+// the given density of cells in the region are alive, or as close to it as
+// the symmetry allows, and all the others are dead.
+func (u *Universe) RandomizeWith(opts RandomOptions) error {
+	if opts.Density < 0 || opts.Density > 100 {
+		return errInvalidDensity
+	}
+
+	region := opts.Region
+	if region.Height == 0 || region.Width == 0 {
+		region = Rect{Height: int(u.height), Width: int(u.width)}
+	}
+	if region.Row < 0 || region.Column < 0 || region.Height < 0 || region.Width < 0 ||
+		region.Row+region.Height > int(u.height) || region.Column+region.Width > int(u.width) {
+		return errInvalidRegion
+	}
+	if (opts.Symmetry == SymmetryC4 || opts.Symmetry == SymmetryD8) && region.Height != region.Width {
+		return errInvalidRegion
+	}
+
+	orbits, err := symmetricOrbits(region.Height, region.Width, opts.Symmetry)
+	if err != nil {
+		return err
+	}
+
+	r := opts.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(randomSeed()))
+	}
+
+	switch opts.Noise {
+	case NoiseUniform:
+		r.Shuffle(len(orbits), func(i, j int) { orbits[i], orbits[j] = orbits[j], orbits[i] })
+	case NoisePerlin:
+		scale := opts.Scale
+		if scale <= 0 {
+			scale = defaultNoiseScale
+		}
+		p := newPerlin(r, region.Height, region.Width, scale)
+		noise := make([]float64, len(orbits))
+		for i, orbit := range orbits {
+			noise[i] = p.at(orbit[0]/region.Width, orbit[0]%region.Width)
+		}
+		sort.Sort(byNoise{orbits, noise})
+	default:
+		return errInvalidNoise
+	}
+
+	alive := (region.Height*region.Width*opts.Density + 50) / 100
+	for _, orbit := range orbits {
+		state := uint8(Dead)
+		if len(orbit) <= alive {
+			state = Alive
+			alive -= len(orbit)
+		}
+		for _, idx := range orbit {
+			row, column := region.Row+idx/region.Width, region.Column+idx%region.Width
+			u.cells[u.GetIndex(uint32(row), uint32(column))] = state
+		}
+	}
+	u.changed()
+
+	return nil
+}
+
+// symmetricOrbits groups the cells of a rectangle, by their index within it,
+// into the sets of cells that the symmetry maps onto each other. The first
+// cell of each orbit is the one with the lowest index.
+func symmetricOrbits(height, width int, s Symmetry) ([][]int, error) {
+	var maps []func(row, column int) (int, int)
+	rotate180 := func(row, column int) (int, int) { return height - 1 - row, width - 1 - column }
+	rotate90 := func(row, column int) (int, int) { return column, width - 1 - row }
+	mirror := func(row, column int) (int, int) { return row, width - 1 - column }
+	switch s {
+	case SymmetryNone:
+	case SymmetryC2:
+		maps = append(maps, rotate180)
+	case SymmetryC4:
+		maps = append(maps, rotate90)
+	case SymmetryD8:
+		maps = append(maps, rotate90, mirror)
+	default:
+		return nil, errInvalidSymmetry
+	}
+
+	visited := make([]bool, height*width)
+	var orbits [][]int
+	for start := range visited {
+		if visited[start] {
+			continue
+		}
+
+		visited[start] = true
+		orbit := []int{start}
+		for i := 0; i < len(orbit); i++ {
+			for _, m := range maps {
+				row, column := m(orbit[i]/width, orbit[i]%width)
+				if idx := row*width + column; !visited[idx] {
+					visited[idx] = true
+					orbit = append(orbit, idx)
+				}
+			}
+		}
+		orbits = append(orbits, orbit)
+	}
+
+	return orbits, nil
+}
+
+// byNoise sorts orbits from the highest to the lowest noise.
+type byNoise struct {
+	orbits [][]int
+	noise  []float64
+}
+
+func (b byNoise) Len() int           { return len(b.orbits) }
+func (b byNoise) Less(i, j int) bool { return b.noise[i] > b.noise[j] }
+func (b byNoise) Swap(i, j int) {
+	b.orbits[i], b.orbits[j] = b.orbits[j], b.orbits[i]
+	b.noise[i], b.noise[j] = b.noise[j], b.noise[i]
+}
+
+// perlin is a grid of random gradients, one every scale cells, that are
+// interpolated into smooth noise.
+// See https://en.wikipedia.org/wiki/Perlin_noise for more information.
+type perlin struct {
+	scale     float64
+	columns   int
+	gradients [][2]float64
+}
+
+func newPerlin(r *rand.Rand, height, width, scale int) *perlin {
+	rows, columns := height/scale+2, width/scale+2
+	p := &perlin{scale: float64(scale), columns: columns, gradients: make([][2]float64, rows*columns)}
+	for i := range p.gradients {
+		angle := r.Float64() * 2 * math.Pi
+		p.gradients[i] = [2]float64{math.Sin(angle), math.Cos(angle)}
 	}
-	return val
+
+	return p
+}
+
+// at returns the noise at the center of a cell, between -1 and 1.
+func (p *perlin) at(row, column int) float64 {
+	y, x := (float64(row)+0.5)/p.scale, (float64(column)+0.5)/p.scale
+	top, left := int(y), int(x)
+
+	dot := func(r, c int) float64 {
+		g := p.gradients[r*p.columns+c]
+		return g[0]*(y-float64(r)) + g[1]*(x-float64(c))
+	}
+	fade := func(t float64) float64 { return t * t * t * (t*(t*6-15) + 10) }
+	lerp := func(a, b, t float64) float64 { return a + (b-a)*t }
+
+	sy, sx := fade(y-float64(top)), fade(x-float64(left))
+	return lerp(
+		lerp(dot(top, left), dot(top, left+1), sx),
+		lerp(dot(top+1, left), dot(top+1, left+1), sx),
+		sy,
+	)
+}
+
+// randomSeed returns a seed for the universes that are not given a source.
+func randomSeed() int64 {
+	var b [8]byte
+	crand.Read(b[:])
+	return int64(binary.LittleEndian.Uint64(b[:]))
 }
@@ -1,15 +1,175 @@
 package game
 
 import (
+	"math/rand"
 	"testing"
 )
 
 func TestRandomness(t *testing.T) {
-	val1 := randomNumber()
-	val2 := randomNumber()
-	val3 := randomNumber()
+	val1 := randomSeed()
+	val2 := randomSeed()
+	val3 := randomSeed()
 
 	if val1 == val2 || val1 == val3 || val2 == val3 {
 		t.Errorf("Expected %d and %d and %d to be different", val1, val2, val3)
 	}
 }
+
+func TestRandomize(t *testing.T) {
+	t.Run("Exact density", func(t *testing.T) {
+		for _, density := range []int{0, 1, 37, 50, 99, 100} {
+			u := NewUniverse(20, 30)
+			u.Randomize(density)
+			if expected := uint32(600 * density / 100); u.Population() != expected {
+				t.Errorf("Expected %d live cells at %d%%, got %d", expected, density, u.Population())
+			}
+		}
+	})
+
+	t.Run("Clamped", func(t *testing.T) {
+		u := NewUniverse(10, 10)
+		u.Randomize(150)
+		if u.Population() != 100 {
+			t.Errorf("Expected every cell to be alive, got %d", u.Population())
+		}
+	})
+}
+
+func TestRandomizeWith(t *testing.T) {
+	t.Run("Seeded", func(t *testing.T) {
+		u1, u2, u3 := NewUniverse(32, 32), NewUniverse(32, 32), NewUniverse(32, 32)
+		u1.RandomizeWith(RandomOptions{Rand: rand.New(rand.NewSource(7)), Density: 40})
+		u2.RandomizeWith(RandomOptions{Rand: rand.New(rand.NewSource(7)), Density: 40})
+		u3.RandomizeWith(RandomOptions{Rand: rand.New(rand.NewSource(8)), Density: 40})
+
+		if u1.String() != u2.String() {
+			t.Errorf("Expected the same seed to fill the same cells")
+		}
+		if u1.String() == u3.String() {
+			t.Errorf("Expected different seeds to fill different cells")
+		}
+	})
+
+	t.Run("Region", func(t *testing.T) {
+		u := NewUniverse(10, 10)
+		u.ToggleCellAt(0, 0)
+		err := u.RandomizeWith(RandomOptions{Density: 100, Region: Rect{Row: 2, Column: 3, Height: 4, Width: 5}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if u.Population() != 21 {
+			t.Errorf("Expected 20 cells in the region and 1 outside, got %d", u.Population())
+		}
+		if u.Cell(u.GetIndex(2, 3)) != Alive || u.Cell(u.GetIndex(5, 7)) != Alive ||
+			u.Cell(u.GetIndex(6, 7)) != Dead || u.Cell(u.GetIndex(5, 8)) != Dead {
+			t.Errorf("Expected only the region to be filled, got\n%s", u)
+		}
+	})
+
+	t.Run("Symmetry", func(t *testing.T) {
+		cases := []struct {
+			symmetry Symmetry
+			height   uint32
+			maps     []func(r, c, n uint32) (uint32, uint32)
+		}{
+			{SymmetryC2, 9, []func(r, c, n uint32) (uint32, uint32){
+				func(r, c, n uint32) (uint32, uint32) { return n - 1 - r, 15 - c },
+			}},
+			{SymmetryC4, 16, []func(r, c, n uint32) (uint32, uint32){
+				func(r, c, n uint32) (uint32, uint32) { return c, n - 1 - r },
+			}},
+			{SymmetryD8, 16, []func(r, c, n uint32) (uint32, uint32){
+				func(r, c, n uint32) (uint32, uint32) { return c, n - 1 - r },
+				func(r, c, n uint32) (uint32, uint32) { return r, n - 1 - c },
+				func(r, c, n uint32) (uint32, uint32) { return c, r },
+			}},
+		}
+
+		for _, tc := range cases {
+			u := NewUniverse(tc.height, 16)
+			u.RandomizeWith(RandomOptions{Rand: rand.New(rand.NewSource(1)), Density: 50, Symmetry: tc.symmetry})
+			if u.Population() == 0 {
+				t.Errorf("Expected %d soup to have live cells", tc.symmetry)
+			}
+
+			for r := uint32(0); r < tc.height; r++ {
+				for c := uint32(0); c < 16; c++ {
+					for _, m := range tc.maps {
+						mr, mc := m(r, c, tc.height)
+						if u.Cell(u.GetIndex(r, c)) != u.Cell(u.GetIndex(mr, mc)) {
+							t.Fatalf("Expected %d soup to be symmetric, got\n%s", tc.symmetry, u)
+						}
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("Perlin", func(t *testing.T) {
+		uniform, perlin := NewUniverse(64, 64), NewUniverse(64, 64)
+		uniform.RandomizeWith(RandomOptions{Rand: rand.New(rand.NewSource(3)), Density: 30})
+		perlin.RandomizeWith(RandomOptions{Rand: rand.New(rand.NewSource(3)), Density: 30, Noise: NoisePerlin})
+
+		if perlin.Population() != uniform.Population() {
+			t.Errorf("Expected the same number of live cells, got %d and %d", perlin.Population(), uniform.Population())
+		}
+
+		// Clustered cells have more live neighbors than scattered ones.
+		neighbors := func(u *Universe) int {
+			count := 0
+			for r := uint32(0); r < 64; r++ {
+				for c := uint32(0); c < 64; c++ {
+					if u.Cell(u.GetIndex(r, c)) == Dead {
+						continue
+					}
+					for dr := int32(-1); dr <= 1; dr++ {
+						for dc := int32(-1); dc <= 1; dc++ {
+							if (dr != 0 || dc != 0) && u.cellAt(int32(r)+dr, int32(c)+dc) != Dead {
+								count++
+							}
+						}
+					}
+				}
+			}
+			return count
+		}
+		if neighbors(perlin) < 2*neighbors(uniform) {
+			t.Errorf("Expected perlin noise to be clustered, got\n%s", perlin)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		u := NewUniverse(8, 10)
+		for _, opts := range []RandomOptions{
+			{Density: -1},
+			{Density: 101},
+			{Density: 50, Region: Rect{Row: 5, Height: 4, Width: 4}},
+			{Density: 50, Symmetry: SymmetryC4},
+			{Density: 50, Symmetry: 9},
+			{Density: 50, Noise: 9},
+		} {
+			if err := u.RandomizeWith(opts); err == nil {
+				t.Errorf("Expected an error for %+v", opts)
+			}
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		var s Symmetry
+		if err := s.UnmarshalText([]byte("D8")); err != nil || s != SymmetryD8 {
+			t.Errorf("Expected D8, got %d and %v", s, err)
+		}
+		if text, _ := SymmetryC2.MarshalText(); string(text) != "C2" {
+			t.Errorf("Expected C2, got %s", text)
+		}
+
+		var n Noise
+		if err := n.UnmarshalText([]byte("perlin")); err != nil || n != NoisePerlin {
+			t.Errorf("Expected perlin, got %d and %v", n, err)
+		}
+		if err := n.UnmarshalText([]byte("pink")); err == nil {
+			t.Errorf("Expected an error for an unknown noise")
+		}
+	})
+}
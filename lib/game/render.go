@@ -0,0 +1,123 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+)
+
+var (
+	// DefaultPalette draws dead cells in white and live cells in black.
+	DefaultPalette = color.Palette{color.White, color.Black}
+
+	defaultGridColor = color.Gray{Y: 0xcc}
+)
+
+// RenderOptions configures how universes and figures are drawn into images.
+type RenderOptions struct {
+	// CellSize is the width and height of a cell in pixels, 1 if zero.
+	CellSize int
+	// Grid draws one pixel wide lines around the cells, in GridColor or in
+	// light gray if it is nil.
+	Grid      bool
+	GridColor color.Color
+	// Palette has the color of each state, starting with Dead. States past
+	// its end use its last color. DefaultPalette is used if it is empty.
+	Palette color.Palette
+	// Delay is the time between the frames of animated GIFs, in hundredths
+	// of a second.
+	Delay int
+}
+
+// palette returns the colors of the states followed by the grid color, so
+// that the images can be encoded as GIFs.
+func (opts RenderOptions) palette() color.Palette {
+	states := opts.Palette
+	if len(states) == 0 {
+		states = DefaultPalette
+	}
+	states = states[:min(len(states), 255)]
+
+	grid := opts.GridColor
+	if grid == nil {
+		grid = defaultGridColor
+	}
+
+	return append(append(color.Palette{}, states...), grid)
+}
+
+// render draws rows of cells, laid out like the canvas of the web frontend.
+func (opts RenderOptions) render(values [][]uint8, width int) *image.Paletted {
+	cellSize := max(opts.CellSize, 1)
+	border := 0
+	if opts.Grid {
+		border = 1
+	}
+
+	palette := opts.palette()
+	grid := uint8(len(palette) - 1)
+	bounds := image.Rect(0, 0, width*(cellSize+border)+border, len(values)*(cellSize+border)+border)
+	img := image.NewPaletted(bounds, palette)
+	if opts.Grid {
+		for i := range img.Pix {
+			img.Pix[i] = grid
+		}
+	}
+
+	for r, row := range values {
+		for c := 0; c < width; c++ {
+			state := uint8(Dead)
+			if c < len(row) {
+				state = min(row[c], grid-1)
+			}
+
+			top, left := r*(cellSize+border)+border, c*(cellSize+border)+border
+			for y := top; y < top+cellSize; y++ {
+				offset := img.PixOffset(left, y)
+				for x := 0; x < cellSize; x++ {
+					img.Pix[offset+x] = state
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// Image draws the cells of the universe.
+func (u *Universe) Image(opts RenderOptions) *image.Paletted {
+	return opts.render(u.rows(), int(u.width))
+}
+
+// Image draws the cells of the figure.
+func (f *Figure) Image(opts RenderOptions) *image.Paletted {
+	return opts.render(f.values, int(f.Width()))
+}
+
+// WritePNG encodes an image of the universe as a PNG.
+func (u *Universe) WritePNG(w io.Writer, opts RenderOptions) error {
+	return png.Encode(w, u.Image(opts))
+}
+
+// WritePNG encodes an image of the figure as a PNG.
+func (f *Figure) WritePNG(w io.Writer, opts RenderOptions) error {
+	return png.Encode(w, f.Image(opts))
+}
+
+// WriteGIF runs the universe for the given number of generations and
+// encodes them as a looping animated GIF, starting with the current one.
+func (u *Universe) WriteGIF(w io.Writer, generations int, opts RenderOptions) error {
+	animation := &gif.GIF{}
+	for i := 0; i <= generations; i++ {
+		if i > 0 {
+			u.Tick()
+		}
+
+		animation.Image = append(animation.Image, u.Image(opts))
+		animation.Delay = append(animation.Delay, opts.Delay)
+	}
+
+	return gif.EncodeAll(w, animation)
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+)
+
+func TestImage(t *testing.T) {
+	t.Run("Cells", func(t *testing.T) {
+		img := Glider().Image(RenderOptions{CellSize: 2})
+		if img.Bounds().Dx() != 6 || img.Bounds().Dy() != 6 {
+			t.Errorf("Expected a 6x6 image, got %v", img.Bounds())
+		}
+		if img.At(2, 0) != color.Black || img.At(3, 1) != color.Black || img.At(0, 0) != color.White {
+			t.Errorf("Expected the glider to be drawn")
+		}
+	})
+
+	t.Run("Grid", func(t *testing.T) {
+		img := Glider().Image(RenderOptions{CellSize: 2, Grid: true})
+		if img.Bounds().Dx() != 10 || img.Bounds().Dy() != 10 {
+			t.Errorf("Expected a 10x10 image, got %v", img.Bounds())
+		}
+		if img.At(0, 0) != defaultGridColor || img.At(3, 0) != defaultGridColor {
+			t.Errorf("Expected gridlines, got %v and %v", img.At(0, 0), img.At(3, 0))
+		}
+		if img.At(4, 1) != color.Black || img.At(1, 1) != color.White {
+			t.Errorf("Expected the glider to be drawn inside the grid")
+		}
+	})
+
+	t.Run("Palette", func(t *testing.T) {
+		red := color.RGBA{R: 0xff, A: 0xff}
+		f := NewFigure([][]uint8{{Dead, Alive, 2, 7}})
+		img := f.Image(RenderOptions{Palette: color.Palette{color.White, color.Black, red}})
+		if img.At(2, 0) != red || img.At(3, 0) != red {
+			t.Errorf("Expected states past the palette to use its last color")
+		}
+	})
+}
+
+func TestWritePNG(t *testing.T) {
+	u := NewUniverse(16, 24)
+	u.Randomize(30)
+
+	buf := &bytes.Buffer{}
+	if err := u.WritePNG(buf, RenderOptions{CellSize: 3}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	img, err := png.Decode(buf)
+	if err != nil {
+		t.Fatalf("Expected a valid PNG, got %v", err)
+	}
+	if img.Bounds().Dx() != 72 || img.Bounds().Dy() != 48 {
+		t.Errorf("Expected a 72x48 image, got %v", img.Bounds())
+	}
+}
+
+func TestWriteGIF(t *testing.T) {
+	u := NewUniverse(16, 16)
+	u.SetRectangle(6, 6, Glider().Values())
+
+	buf := &bytes.Buffer{}
+	if err := u.WriteGIF(buf, 4, RenderOptions{Grid: true, Delay: 10}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	animation, err := gif.DecodeAll(buf)
+	if err != nil {
+		t.Fatalf("Expected a valid GIF, got %v", err)
+	}
+	if len(animation.Image) != 5 || animation.Delay[0] != 10 {
+		t.Errorf("Expected 5 frames of 10, got %d frames of %d", len(animation.Image), animation.Delay[0])
+	}
+	if u.Generation != 4 {
+		t.Errorf("Expected the universe to be at generation 4, got %d", u.Generation)
+	}
+}
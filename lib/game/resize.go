@@ -0,0 +1,108 @@
+package game
+
+// Anchor is the point of a universe that stays in place when it is resized.
+type Anchor uint8
+
+const (
+	AnchorTopLeft Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+)
+
+// Resize changes the size of the universe, keeping its cells in place
+// relative to the anchor. Cells that no longer fit are lost, and new cells
+// are dead. The generation and rules are kept, while activity tracking
+// starts over. Universes with neighbors cannot be resized.
+func (u *Universe) Resize(height, width uint32, anchor Anchor) error {
+	if anchor > AnchorBottomRight {
+		return errInvalidAnchor
+	}
+	if u.outside != nil {
+		return errResizeNeighbors
+	}
+
+	// Anchors on the bottom or the right move the cells by the whole
+	// difference in size, the ones in the middle by half of it.
+	rows := (int(height) - int(u.height)) * int(anchor/3) / 2
+	columns := (int(width) - int(u.width)) * int(anchor%3) / 2
+
+	cells := u.moved(height, width, rows, columns, false)
+	u.allocate(height, width)
+	copy(u.cells, cells)
+	u.changed()
+	return nil
+}
+
+// Crop shrinks the universe to the bounding box of its live cells, and
+// returns where the box was. The generation and rules are kept, while
+// activity tracking starts over. Universes with neighbors or without live
+// cells cannot be cropped.
+func (u *Universe) Crop() (Rect, error) {
+	if u.outside != nil {
+		return Rect{}, errResizeNeighbors
+	}
+
+	values, row, column := trimValues(u.rows())
+	if len(values) == 0 {
+		return Rect{}, errEmptyUniverse
+	}
+
+	bounds := Rect{Row: row, Column: column, Height: len(values), Width: len(values[0])}
+	u.allocate(uint32(bounds.Height), uint32(bounds.Width))
+	u.SetRectangle(0, 0, values)
+	return bounds, nil
+}
+
+// Shift moves every cell of the universe by the given number of rows and
+// columns, towards the bottom and the right when they are positive. With
+// BoundaryWrap the cells that go past an edge come back on the opposite
+// one, with BoundaryDead they are lost. The generation and rules are kept,
+// while activity tracking starts over.
+func (u *Universe) Shift(rows, columns int, boundary Boundary) error {
+	if boundary != BoundaryDead && boundary != BoundaryWrap {
+		return errInvalidBoundary
+	}
+
+	copy(u.cells, u.moved(u.height, u.width, rows, columns, boundary == BoundaryWrap))
+	if u.activity != nil {
+		u.activity = newActivity(len(u.cells))
+	}
+	u.changed()
+	return nil
+}
+
+// moved returns the cells of the universe moved by the given number of rows
+// and columns into a grid of the given size, wrapping them around its
+// edges or dropping the ones that fall outside.
+func (u *Universe) moved(height, width uint32, rows, columns int, wrap bool) []uint8 {
+	cells := make([]uint8, height*width)
+	if height == 0 || width == 0 {
+		return cells
+	}
+
+	for r := 0; r < int(u.height); r++ {
+		for c := 0; c < int(u.width); c++ {
+			cell := u.cells[r*int(u.width)+c]
+			if cell == Dead {
+				continue
+			}
+
+			row, column := r+rows, c+columns
+			if wrap {
+				row = (row%int(height) + int(height)) % int(height)
+				column = (column%int(width) + int(width)) % int(width)
+			} else if row < 0 || row >= int(height) || column < 0 || column >= int(width) {
+				continue
+			}
+			cells[row*int(width)+column] = cell
+		}
+	}
+
+	return cells
+}
@@ -0,0 +1,139 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	t.Run("Anchors", func(t *testing.T) {
+		cases := []struct {
+			anchor      Anchor
+			row, column uint32
+		}{
+			{AnchorTopLeft, 1, 1},
+			{AnchorCenter, 3, 4},
+			{AnchorBottomRight, 5, 7},
+			{AnchorBottom, 5, 4},
+			{AnchorRight, 3, 7},
+		}
+
+		for _, tc := range cases {
+			u := NewUniverse(6, 6)
+			u.SetRectangle(1, 1, Glider().Values())
+			u.Generation = 7
+
+			if err := u.Resize(10, 12, tc.anchor); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if u.Height() != 10 || u.Width() != 12 || u.Generation != 7 {
+				t.Errorf("Expected a 10x12 universe at generation 7, got %dx%d at %d", u.Height(), u.Width(), u.Generation)
+			}
+
+			expected := NewUniverse(10, 12)
+			expected.SetRectangle(tc.row, tc.column, Glider().Values())
+			if patch, err := expected.Diff(u); err != nil || !patch.Empty() {
+				t.Errorf("Expected the glider at %d, %d for anchor %d, got %v and differences\n%s", tc.row, tc.column, tc.anchor, err, patch)
+			}
+		}
+	})
+
+	t.Run("Shrink", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		u.SetRectangle(4, 4, Glider().Values())
+		u.Resize(6, 6, AnchorTopLeft)
+
+		if u.Population() != 1 {
+			t.Errorf("Expected the cells outside to be lost, got\n%s", u)
+		}
+	})
+
+	t.Run("Keeps rules", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		u.SetRectangle(1, 0, [][]uint8{{Alive, Alive, Alive}})
+		u.Resize(9, 9, AnchorCenter)
+		u.Tick()
+
+		expected := NewUniverse(9, 9)
+		expected.SetRectangle(0, 1, [][]uint8{{Alive}, {Alive}, {Alive}})
+		if !u.Equal(expected) {
+			t.Errorf("Expected the blinker to oscillate, got\n%s", u)
+		}
+
+		rule, _ := ParseRule("B36/S23")
+		u.SetRule(rule)
+		u.Resize(12, 12, AnchorTopLeft)
+		if u.Rule() == nil || u.Rule().String() != "B36/S23" {
+			t.Errorf("Expected the rule to be kept, got %v", u.Rule())
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if err := NewUniverse(4, 4).Resize(5, 5, 9); err != errInvalidAnchor {
+			t.Errorf("Expected error to be %v, got %v", errInvalidAnchor, err)
+		}
+
+		p := NewParallelUniverse(4, 4)
+		if err := p.Resize(5, 5, AnchorCenter); err != errResizeNeighbors {
+			t.Errorf("Expected error to be %v, got %v", errResizeNeighbors, err)
+		}
+	})
+}
+
+func TestCrop(t *testing.T) {
+	u := NewUniverse(16, 16)
+	u.SetRectangle(4, 6, Beehive().Values())
+	u.Generation = 3
+
+	bounds, err := u.Crop()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bounds != (Rect{Row: 4, Column: 6, Height: 3, Width: 4}) {
+		t.Errorf("Expected the beehive bounds, got %+v", bounds)
+	}
+	if u.String() != Beehive().String() || u.Generation != 3 {
+		t.Errorf("Expected only the beehive at generation 3, got %d\n%s", u.Generation, u)
+	}
+
+	if _, err := NewUniverse(4, 4).Crop(); err != errEmptyUniverse {
+		t.Errorf("Expected error to be %v, got %v", errEmptyUniverse, err)
+	}
+}
+
+func TestShift(t *testing.T) {
+	t.Run("Dead", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		u.SetRectangle(1, 1, Glider().Values())
+		u.Shift(2, 3, BoundaryDead)
+
+		expected := NewUniverse(8, 8)
+		expected.SetRectangle(3, 4, Glider().Values())
+		if patch, err := expected.Diff(u); err != nil || !patch.Empty() {
+			t.Errorf("Expected the glider to move, got %v and differences\n%s", err, patch)
+		}
+
+		u.Shift(-6, 0, BoundaryDead)
+		if u.Population() != 0 {
+			t.Errorf("Expected the glider to be lost past the edge, got\n%s", u)
+		}
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		u.SetRectangle(1, 1, Glider().Values())
+		u.Shift(-3, 10, BoundaryWrap)
+
+		expected := NewUniverse(8, 8)
+		expected.SetRectangle(1, 1, Glider().Values())
+		expected.Shift(5, 2, BoundaryWrap)
+		if !u.Equal(expected) || u.Population() != 5 {
+			t.Errorf("Expected the glider to wrap, got\n%s", u)
+		}
+	})
+
+	t.Run("Invalid boundary", func(t *testing.T) {
+		if err := NewUniverse(4, 4).Shift(1, 1, 5); err != errInvalidBoundary {
+			t.Errorf("Expected error to be %v, got %v", errInvalidBoundary, err)
+		}
+	})
+}
@@ -0,0 +1,358 @@
+package game
+
+import (
+	"strconv"
+	"strings"
+)
+
+// rleLineLength is the maximum length of the lines of cells written in RLE.
+const rleLineLength = 70
+
+// ParseRLE reads a pattern in the run length encoded format, including its
+// "#N", "#O" and "#C" metadata lines and its "x = , y = , rule = " header.
+// Both the two-state "b" and "o" cells and the multi-state "." and "A" to
+// "X" cells, with their "p" to "y" prefixes, are accepted. Patterns that do
+// not fit the size in their header, or cover more than 4096 by 4096 cells,
+// return errInvalidLength.
+// See https://conwaylife.com/wiki/Run_Length_Encoded for more information.
+func ParseRLE(data string) (*Pattern, error) {
+	p := &Pattern{}
+	lines := strings.Split(data, "\n")
+
+	i := 0
+	var width, height int
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#"):
+			p.parseComment(line)
+			continue
+		case strings.HasPrefix(line, "x"):
+			var err error
+			width, height, p.Rule, err = parseRLEHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			i++
+		}
+		break
+	}
+
+	if width > maxDecodedCells || height > maxDecodedRows || width*height > maxDecodedCells {
+		return nil, errInvalidLength
+	}
+	values, err := parseRLECells(strings.Join(lines[i:], "\n"), height, width)
+	if err != nil {
+		return nil, err
+	}
+	for len(values) < height {
+		values = append(values, nil)
+	}
+	for _, row := range values {
+		width = max(width, len(row))
+	}
+	if len(values) > 0 && len(values[0]) < width {
+		values[0] = append(values[0], make([]uint8, width-len(values[0]))...)
+	}
+
+	p.Figure = NewFigure(padValues(values))
+	return p, nil
+}
+
+// parseComment reads the metadata of a "#" line shared by the RLE and Life
+// 1.05 formats.
+func (p *Pattern) parseComment(line string) {
+	if len(line) < 2 {
+		return
+	}
+
+	text := strings.TrimSpace(line[2:])
+	switch line[1] {
+	case 'N':
+		p.Name = text
+	case 'O':
+		p.Author = text
+	case 'C', 'c', 'D':
+		p.Comments = append(p.Comments, text)
+	case 'r':
+		p.Rule = text
+	}
+}
+
+func parseRLEHeader(line string) (width, height int, rule string, err error) {
+	for _, field := range strings.Split(line, ",") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return 0, 0, "", errInvalidHeader
+		}
+
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "x":
+			width, err = strconv.Atoi(value)
+		case "y":
+			height, err = strconv.Atoi(value)
+		case "rule":
+			rule = value
+		}
+		if err != nil || width < 0 || height < 0 {
+			return 0, 0, "", errInvalidHeader
+		}
+	}
+
+	return width, height, rule, nil
+}
+
+// parseRLECells reads the cells of an RLE pattern into rows of at most
+// width cells, and at most height rows. A height or width of 0 leaves it
+// unbounded, but the rows never cover more than maxDecodedCells cells or
+// maxDecodedRows rows, so that untrusted runs cannot allocate too much.
+// Runs past the bounds return errInvalidLength before any cell is added.
+func parseRLECells(data string, height, width int) ([][]uint8, error) {
+	if height == 0 || height > maxDecodedRows {
+		height = maxDecodedRows
+	}
+
+	var values [][]uint8
+	row, columns := 0, 1
+	count := 0
+	var prefix byte
+
+	for i := 0; i < len(data); i++ {
+		char := data[i]
+		if char >= '0' && char <= '9' {
+			if count > maxDecodedCells {
+				return nil, errInvalidLength
+			}
+			count = count*10 + int(char-'0')
+			continue
+		}
+
+		n := max(count, 1)
+		switch {
+		case char == ' ' || char == '\t' || char == '\r' || char == '\n':
+			continue
+		case char == '!':
+			return finishRLECells(values, row, height), nil
+		case char == '$':
+			// Empty rows only take memory once a cell follows them, and
+			// the ones past the height are dropped if none does.
+			row = min(row+n, height)
+		case char >= 'p' && char <= 'y':
+			if prefix != 0 {
+				return nil, errInvalidCharacter
+			}
+			prefix = char
+			continue
+		default:
+			state, ok := rleState(prefix, char)
+			if !ok {
+				return nil, errInvalidCharacter
+			}
+
+			if row >= height {
+				return nil, errInvalidLength
+			}
+			for len(values) <= row {
+				values = append(values, []uint8{})
+			}
+			end := len(values[row]) + n
+			if (width > 0 && end > width) || (row+1)*max(columns, end) > maxDecodedCells {
+				return nil, errInvalidLength
+			}
+			columns = max(columns, end)
+			for j := 0; j < n; j++ {
+				values[row] = append(values[row], state)
+			}
+		}
+		count = 0
+		prefix = 0
+	}
+
+	return finishRLECells(values, row, height), nil
+}
+
+// finishRLECells adds the empty rows up to the last one, but not past the
+// height.
+func finishRLECells(values [][]uint8, row, height int) [][]uint8 {
+	for len(values) < min(row+1, height) {
+		values = append(values, []uint8{})
+	}
+
+	return values
+}
+
+// rleState returns the state of a cell tag, optionally with a prefix.
+func rleState(prefix, char byte) (uint8, bool) {
+	switch {
+	case prefix == 0 && (char == 'b' || char == '.'):
+		return Dead, true
+	case prefix == 0 && char == 'o':
+		return Alive, true
+	case char >= 'A' && char <= 'X':
+		state := int(char-'A') + 1
+		if prefix != 0 {
+			state += int(prefix-'p'+1) * 24
+		}
+		if state > 255 {
+			return 0, false
+		}
+		return uint8(state), true
+	default:
+		return 0, false
+	}
+}
+
+// rleTag returns the tag of a cell state, in the multi-state notation if
+// multiState is true.
+func rleTag(state uint8, multiState bool) string {
+	switch {
+	case !multiState && state == Dead:
+		return "b"
+	case !multiState:
+		return "o"
+	case state == Dead:
+		return "."
+	case state <= 24:
+		return string(rune('A' + state - 1))
+	default:
+		return string(rune('p'+(state-1)/24-1)) + string(rune('A'+(state-1)%24))
+	}
+}
+
+// RLE returns the pattern in the run length encoded format, with its
+// metadata, and lines of cells wrapped at 70 characters.
+func (p *Pattern) RLE() string {
+	builder := strings.Builder{}
+	if p.Name != "" {
+		builder.WriteString("#N " + p.Name + "\n")
+	}
+	if p.Author != "" {
+		builder.WriteString("#O " + p.Author + "\n")
+	}
+	for _, comment := range p.Comments {
+		builder.WriteString("#C " + comment + "\n")
+	}
+
+	builder.WriteString("x = " + strconv.Itoa(int(p.Width())) + ", y = " + strconv.Itoa(int(p.Height())))
+	if p.Rule != "" {
+		builder.WriteString(", rule = " + p.Rule)
+	}
+	builder.WriteString("\n")
+
+	writeRLECells(&builder, p.values)
+	return builder.String()
+}
+
+func writeRLECells(builder *strings.Builder, values [][]uint8) {
+	multiState := false
+	for _, row := range values {
+		for _, cell := range row {
+			multiState = multiState || cell > Alive
+		}
+	}
+
+	var tokens []string
+	emptyRows := 0
+	for r, row := range values {
+		// Trailing dead cells are implied by the end of the row.
+		end := len(row)
+		for end > 0 && row[end-1] == Dead {
+			end--
+		}
+
+		if end == 0 && r > 0 {
+			emptyRows++
+			continue
+		}
+		if r > 0 {
+			tokens = append(tokens, rleRun(emptyRows+1, "$"))
+		}
+		emptyRows = 0
+
+		for start := 0; start < end; {
+			run := start
+			for run < end && row[run] == row[start] {
+				run++
+			}
+			tokens = append(tokens, rleRun(run-start, rleTag(row[start], multiState)))
+			start = run
+		}
+	}
+	tokens = append(tokens, "!")
+
+	lineLength := 0
+	for _, token := range tokens {
+		if lineLength+len(token) > rleLineLength {
+			builder.WriteString("\n")
+			lineLength = 0
+		}
+		builder.WriteString(token)
+		lineLength += len(token)
+	}
+	builder.WriteString("\n")
+}
+
+func rleRun(count int, tag string) string {
+	if count == 1 {
+		return tag
+	}
+
+	return strconv.Itoa(count) + tag
+}
+
+// RLE returns the figure in the run length encoded format.
+func (f *Figure) RLE() string {
+	return NewPattern(f).RLE()
+}
+
+// RLE returns the cells of the universe in the run length encoded format,
+// with the rulestring of its compiled rule if it has one.
+func (u *Universe) RLE() string {
+	p := NewPattern(NewFigure(u.rows()))
+	if u.rule != nil {
+		p.Rule = u.rule.String()
+	}
+
+	return p.RLE()
+}
+
+// ParseRLE replaces the cells of the universe with a pattern in the run
+// length encoded format, placed in the top-left corner. If the pattern
+// has a rule, it is compiled and set on the universe.
+func (u *Universe) ParseRLE(data string) error {
+	p, err := ParseRLE(data)
+	if err != nil {
+		return err
+	}
+
+	return u.setPattern(p)
+}
+
+// setPattern replaces the cells of the universe with the pattern, placed
+// in the top-left corner, and sets the rule of the pattern if it has one.
+func (u *Universe) setPattern(p *Pattern) error {
+	if p.Height() > u.height || p.Width() > u.width {
+		return errInvalidLength
+	}
+
+	var rule *Rule
+	if p.Rule != "" {
+		var err error
+		if rule, err = LookupRule(p.Rule); err != nil {
+			return err
+		}
+	}
+
+	for i := range u.cells {
+		u.cells[i] = Dead
+	}
+	u.SetRectangle(0, 0, p.values)
+	if rule != nil {
+		u.SetRule(rule)
+	}
+
+	return nil
+}
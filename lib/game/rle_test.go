@@ -0,0 +1,153 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRLE(t *testing.T) {
+	t.Run("Glider", func(t *testing.T) {
+		p, err := ParseRLE("#N Glider\n#O Richard K. Guy\n#C The smallest spaceship.\nx = 3, y = 3, rule = B3/S23\nbob$2bo$3o!\n")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if p.Name != "Glider" || p.Author != "Richard K. Guy" || p.Rule != "B3/S23" {
+			t.Errorf("Expected metadata to be parsed, got %q, %q, %q", p.Name, p.Author, p.Rule)
+		}
+		if len(p.Comments) != 1 || p.Comments[0] != "The smallest spaceship." {
+			t.Errorf("Expected one comment, got %q", p.Comments)
+		}
+		if p.String() != Glider().String() {
+			t.Errorf("Expected the glider, got:\n%s", p)
+		}
+	})
+
+	t.Run("Padding", func(t *testing.T) {
+		p, err := ParseRLE("x = 4, y = 4\no2$\n3bo!")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := "O...\n....\n...O\n....\n"
+		if p.String() != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, p)
+		}
+	})
+
+	t.Run("Trailing rows", func(t *testing.T) {
+		p, err := ParseRLE("x = 1, y = 2\no$o$$!")
+		if err != nil || p.Height() != 2 {
+			t.Errorf("Expected the empty rows past the height to be dropped, got %v", err)
+		}
+	})
+
+	t.Run("Multi-state", func(t *testing.T) {
+		p, err := ParseRLE("x = 4, y = 1\n.ApAyO!")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []uint8{Dead, 1, 25, 255}
+		for i, state := range expected {
+			if p.values[0][i] != state {
+				t.Errorf("Expected cell %d to be %d, got %d", i, state, p.values[0][i])
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for input, expected := range map[string]error{
+			"x = 3, y\nooo!":                     errInvalidHeader,
+			"x = a, y = 1\nooo!":                 errInvalidHeader,
+			"x = 3, y = 1\noZo!":                 errInvalidCharacter,
+			"x = 3, y = 1\nppA!":                 errInvalidCharacter,
+			"x = 3, y = 3\n999999999o!":          errInvalidLength,
+			"x = 3, y = 3\no$o$o$o!":             errInvalidLength,
+			"x = 3, y = 3\n999999999$o!":         errInvalidLength,
+			"x = 100000, y = 100000\no!":         errInvalidLength,
+			"999999999o!":                        errInvalidLength,
+			"5000b$5000$o!":                      errInvalidLength,
+			"99999999999999999999999999999999o!": errInvalidLength,
+		} {
+			if _, err := ParseRLE(input); err != expected {
+				t.Errorf("Expected %q to return %v, got %v", input, expected, err)
+			}
+		}
+	})
+}
+
+func TestRLE(t *testing.T) {
+	t.Run("Glider", func(t *testing.T) {
+		expected := "x = 3, y = 3\nbo$2bo$3o!\n"
+		if Glider().RLE() != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, Glider().RLE())
+		}
+	})
+
+	t.Run("Empty rows", func(t *testing.T) {
+		f := NewFigure([][]uint8{{Alive}, {Dead}, {Dead}, {Alive, Alive}})
+		expected := "x = 2, y = 4\no3$2o!\n"
+		if f.RLE() != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, f.RLE())
+		}
+	})
+
+	t.Run("Multi-state", func(t *testing.T) {
+		f := NewFigure([][]uint8{{Dead, 2, 2, 25, 255}})
+		expected := "x = 5, y = 1\n.2BpAyO!\n"
+		if f.RLE() != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, f.RLE())
+		}
+	})
+
+	t.Run("Line length", func(t *testing.T) {
+		u := NewUniverse(64, 64)
+		u.Randomize(50)
+
+		lines := strings.Split(strings.TrimSpace(u.RLE()), "\n")
+		for _, line := range lines[1:] {
+			if len(line) > rleLineLength {
+				t.Errorf("Expected lines of at most %d characters, got %d", rleLineLength, len(line))
+			}
+		}
+	})
+}
+
+func TestUniverseRLE(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		u := NewUniverse(32, 48)
+		u.Randomize(40)
+
+		u2 := NewUniverse(32, 48)
+		if err := u2.ParseRLE(u.RLE()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if u.String() != u2.String() {
+			t.Errorf("Expected:\n%s\ngot:\n%s", u, u2)
+		}
+	})
+
+	t.Run("Rule", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		if err := u.ParseRLE("x = 3, y = 1, rule = B36/S23\n3o!"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if u.Rule() == nil || u.Rule().String() != "B36/S23" {
+			t.Errorf("Expected rule to be B36/S23, got %v", u.Rule())
+		}
+		if !strings.HasPrefix(u.RLE(), "x = 8, y = 8, rule = B36/S23\n") {
+			t.Errorf("Expected the header to have the rule, got %q", u.RLE())
+		}
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		u := NewUniverse(2, 2)
+		if err := u.ParseRLE("3o!"); err != errInvalidLength {
+			t.Errorf("Expected error to be %v, got %v", errInvalidLength, err)
+		}
+		if err := u.ParseRLE("x = 3, y = 3\n999999999o!"); err != errInvalidLength {
+			t.Errorf("Expected error to be %v, got %v", errInvalidLength, err)
+		}
+	})
+}
@@ -0,0 +1,390 @@
+package game
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A compiled rule looks up the next state of a cell in a table indexed by
+// its neighborhood. For two-dimensional rules the index has one bit per cell
+// of the 3x3 window around the cell, set when that cell is not dead:
+//
+//	0 1 2
+//	3 4 5
+//	6 7 8
+//
+// Bit 4 is the cell itself. Elementary (one-dimensional) rules use a 3-bit
+// index made of the previous, current and next cell of the same row, in that
+// order from the most significant bit, as in Wolfram's numbering.
+const (
+	mooreTableSize      = 1 << 9
+	elementaryTableSize = 1 << 3
+
+	centerBit   = 1 << 4
+	columnMask  = 0b011011011
+	neighborsOf = mooreTableSize - 1 - centerBit
+)
+
+// Rule is a cellular automaton rule compiled into a lookup table, so that
+// every rule runs at the same speed regardless of how it was described.
+type Rule struct {
+	name       string
+	elementary bool
+	table      []uint8
+}
+
+// String returns the canonical rulestring of the rule, e.g. "B3/S23".
+func (r *Rule) String() string {
+	return r.name
+}
+
+// Elementary returns true for one-dimensional rules such as W30.
+func (r *Rule) Elementary() bool {
+	return r.elementary
+}
+
+func (r *Rule) next(neighborhood uint16) uint8 {
+	return r.table[neighborhood]
+}
+
+// henselLetters lists, for 0 to 4 live neighbors, the letters of the
+// isotropic non-totalistic (Hensel) notation, and henselNeighborhoods a
+// representative neighborhood for each letter, in the same order. The classes
+// for 5 to 8 neighbors are the complements of the ones for 8-n.
+// See https://conwaylife.com/wiki/Isotropic_non-totalistic_rule
+var (
+	henselLetters = [5]string{"", "ce", "ceaikn", "ceaiknjqry", "ceaiknjqrtwyz"}
+
+	henselNeighborhoods = [5][]uint16{
+		{0},
+		{1, 2},
+		{5, 10, 3, 40, 33, 68},
+		{69, 42, 11, 7, 98, 13, 14, 70, 41, 97},
+		{325, 170, 15, 45, 99, 71, 106, 102, 43, 101, 105, 78, 108},
+	}
+
+	// henselClass maps a neighborhood without its center bit to the index
+	// of its letter in henselLetters. Counts 0 and 8 have a single class,
+	// which gets index 0.
+	henselClass [mooreTableSize]uint8
+)
+
+func init() {
+	for count := 1; count < 8; count++ {
+		base := count
+		if base > 4 {
+			base = 8 - count
+		}
+
+		for i, neighborhood := range henselNeighborhoods[base] {
+			if count > 4 {
+				neighborhood = neighborsOf &^ neighborhood
+			}
+			for _, n := range symmetries(neighborhood) {
+				henselClass[n] = uint8(i)
+			}
+		}
+	}
+}
+
+// symmetries returns the eight rotations and reflections of a neighborhood.
+func symmetries(neighborhood uint16) []uint16 {
+	result := make([]uint16, 0, 8)
+	n := neighborhood
+	for i := 0; i < 4; i++ {
+		result = append(result, n, reflectNeighborhood(n))
+		n = rotateNeighborhood(n)
+	}
+
+	return result
+}
+
+func rotateNeighborhood(n uint16) uint16 {
+	var out uint16
+	for bit := 0; bit < 9; bit++ {
+		if n&(1<<bit) != 0 {
+			row, column := bit/3, bit%3
+			out |= 1 << (column*3 + 2 - row)
+		}
+	}
+
+	return out
+}
+
+func reflectNeighborhood(n uint16) uint16 {
+	var out uint16
+	for bit := 0; bit < 9; bit++ {
+		if n&(1<<bit) != 0 {
+			row, column := bit/3, bit%3
+			out |= 1 << (row*3 + 2 - column)
+		}
+	}
+
+	return out
+}
+
+func liveNeighbors(neighborhood uint16) int {
+	count := 0
+	for n := neighborhood & neighborsOf; n != 0; n &= n - 1 {
+		count++
+	}
+
+	return count
+}
+
+func henselLettersFor(count int) string {
+	if count > 4 {
+		count = 8 - count
+	}
+
+	return henselLetters[count]
+}
+
+// allClasses returns the transitions mask with every class of count set.
+func allClasses(count int) uint16 {
+	letters := henselLettersFor(count)
+	if letters == "" {
+		return 1
+	}
+
+	return 1<<len(letters) - 1
+}
+
+// transitions holds, for each number of live neighbors, a mask of the Hensel
+// classes for which a transition happens, indexed like henselClass.
+type transitions [9]uint16
+
+// ParseRule compiles a rulestring into a Rule. It accepts Life-like rules in
+// B/S notation ("B3/S23", "b36s23"), the older S/B notation ("23/3"),
+// isotropic non-totalistic rules in Hensel notation ("B2-a3/S12") and
+// elementary rules as a W followed by Wolfram's rule number ("W30").
+func ParseRule(rulestring string) (*Rule, error) {
+	s := strings.TrimSpace(rulestring)
+	if len(s) > 1 && (s[0] == 'W' || s[0] == 'w') {
+		return parseElementaryRule(s[1:])
+	}
+
+	var birth, survival string
+	switch {
+	case strings.ContainsAny(s, "bBsS"):
+		for _, part := range strings.Split(s, "/") {
+			if len(part) == 0 {
+				return nil, errInvalidRule
+			}
+			if i := strings.IndexAny(part, "sS"); i > 0 && (part[0] == 'b' || part[0] == 'B') {
+				// No separator between the two halves, as in "b3s23".
+				birth, survival = part[1:i], part[i+1:]
+				continue
+			}
+			switch part[0] {
+			case 'b', 'B':
+				birth = part[1:]
+			case 's', 'S':
+				survival = part[1:]
+			default:
+				return nil, errInvalidRule
+			}
+		}
+	case strings.Count(s, "/") == 1:
+		survival, birth, _ = strings.Cut(s, "/")
+	default:
+		return nil, errInvalidRule
+	}
+
+	b, err := parseTransitions(birth)
+	if err != nil {
+		return nil, err
+	}
+	sv, err := parseTransitions(survival)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Rule{
+		name:  "B" + b.String() + "/S" + sv.String(),
+		table: make([]uint8, mooreTableSize),
+	}
+	for neighborhood := range r.table {
+		transition := b
+		if neighborhood&centerBit != 0 {
+			transition = sv
+		}
+		if transition.match(uint16(neighborhood)) {
+			r.table[neighborhood] = Alive
+		}
+	}
+
+	return r, nil
+}
+
+func parseElementaryRule(number string) (*Rule, error) {
+	n, err := strconv.ParseUint(number, 10, 8)
+	if err != nil {
+		return nil, errInvalidRule
+	}
+
+	r := &Rule{
+		name:       "W" + strconv.FormatUint(n, 10),
+		elementary: true,
+		table:      make([]uint8, elementaryTableSize),
+	}
+	for i := range r.table {
+		if n&(1<<i) != 0 {
+			r.table[i] = Alive
+		}
+	}
+
+	return r, nil
+}
+
+func parseTransitions(s string) (transitions, error) {
+	var t transitions
+	for i := 0; i < len(s); {
+		if s[i] < '0' || s[i] > '8' {
+			return t, errInvalidRule
+		}
+		count := int(s[i] - '0')
+		i++
+
+		negate := i < len(s) && s[i] == '-'
+		if negate {
+			i++
+		}
+		var mask uint16
+		for ; i < len(s) && s[i] >= 'a' && s[i] <= 'z'; i++ {
+			letter := strings.IndexByte(henselLettersFor(count), s[i])
+			if letter < 0 {
+				return t, errInvalidRule
+			}
+			mask |= 1 << letter
+		}
+
+		switch {
+		case negate && mask == 0:
+			return t, errInvalidRule
+		case negate:
+			t[count] |= allClasses(count) &^ mask
+		case mask == 0:
+			t[count] = allClasses(count)
+		default:
+			t[count] |= mask
+		}
+	}
+
+	return t, nil
+}
+
+func (t transitions) match(neighborhood uint16) bool {
+	class := henselClass[neighborhood&neighborsOf]
+	return t[liveNeighbors(neighborhood)]&(1<<class) != 0
+}
+
+// String returns the canonical form of the transitions: a plain digit when
+// all classes are included, otherwise the shorter of the included letters
+// and the excluded ones prefixed with a minus sign.
+func (t transitions) String() string {
+	builder := strings.Builder{}
+	for count, mask := range t {
+		if mask == 0 {
+			continue
+		}
+
+		builder.WriteString(strconv.Itoa(count))
+		var included, excluded string
+		for i, l := range henselLettersFor(count) {
+			if mask&(1<<i) != 0 {
+				included += string(l)
+			} else {
+				excluded += string(l)
+			}
+		}
+		switch {
+		case excluded == "":
+		case len(excluded) < len(included):
+			builder.WriteString("-" + excluded)
+		default:
+			builder.WriteString(included)
+		}
+	}
+
+	return builder.String()
+}
+
+// conwayRule is B3/S23, used when no rule is given. It is not looked up in
+// the registry, where "conway" can be registered again.
+var conwayRule *Rule
+
+// registry holds the rules registered by name. It is guarded by
+// registryMu, since rules can be registered while soups run concurrently.
+var (
+	registry   = map[string]*Rule{}
+	registryMu sync.RWMutex
+)
+
+func init() {
+	for name, rulestring := range map[string]string{
+		"conway":           "B3/S23",
+		"highlife":         "B36/S23",
+		"seeds":            "B2/S",
+		"dayandnight":      "B3678/S34678",
+		"lifewithoutdeath": "B3/S012345678",
+		"maze":             "B3/S12345",
+		"2x2":              "B36/S125",
+		"rule30":           "W30",
+		"rule90":           "W90",
+		"rule110":          "W110",
+		"rule184":          "W184",
+	} {
+		if err := RegisterRule(name, rulestring); err != nil {
+			panic(err)
+		}
+	}
+	conwayRule = registry["conway"]
+}
+
+// RegisterRule compiles rulestring and makes it available to LookupRule
+// under the given name. Names are case-insensitive.
+func RegisterRule(name, rulestring string) error {
+	r, err := ParseRule(rulestring)
+	if err != nil {
+		return err
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(name)] = r
+	return nil
+}
+
+// LookupRule returns the rule registered under name. If no rule has been
+// registered with that name, name is parsed as a rulestring instead.
+func LookupRule(name string) (*Rule, error) {
+	if r := registeredRule(name); r != nil {
+		return r, nil
+	}
+
+	return ParseRule(name)
+}
+
+// registeredRule returns the rule registered under name, or nil.
+func registeredRule(name string) *Rule {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registry[strings.ToLower(name)]
+}
+
+// RegisteredRules returns the sorted names of all registered rules.
+func RegisteredRules() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,218 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestParseRule(t *testing.T) {
+	t.Run("Canonical names", func(t *testing.T) {
+		for input, expected := range map[string]string{
+			"B3/S23":                  "B3/S23",
+			"b3s23":                   "B3/S23",
+			"S23/B3":                  "B3/S23",
+			"23/3":                    "B3/S23",
+			"B2/S":                    "B2/S",
+			"B3678/S34678":            "B3678/S34678",
+			"B2-a3/S12":               "B2-a3/S12",
+			"B2cekin3/S12":            "B2-a3/S12",
+			"B3cekainyqjr/S2ceaikn3":  "B3/S23",
+			"B3/S2-c3":                "B3/S2-c3",
+			"W30":                     "W30",
+			"w110":                    "W110",
+			"B0123456789/S0123456789": "",
+		} {
+			r, err := ParseRule(input)
+			if expected == "" {
+				if err == nil {
+					t.Errorf("Expected %q to be invalid, got %v", input, r)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("Expected %q to parse, got %v", input, err)
+				continue
+			}
+			if r.String() != expected {
+				t.Errorf("Expected %q to be named %q, got %q", input, expected, r.String())
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, input := range []string{"", "conway", "B3/S2x", "B1a/S", "W256", "B2-/S"} {
+			if _, err := ParseRule(input); err != errInvalidRule {
+				t.Errorf("Expected %q to return %v, got %v", input, errInvalidRule, err)
+			}
+		}
+	})
+
+	t.Run("Table matches RuleB3S23", func(t *testing.T) {
+		r, _ := ParseRule("B3/S23")
+		for neighborhood := uint16(0); neighborhood < mooreTableSize; neighborhood++ {
+			cell := uint8(Dead)
+			if neighborhood&centerBit != 0 {
+				cell = Alive
+			}
+
+			expected := RuleB3S23(cell, uint8(liveNeighbors(neighborhood)))
+			if r.next(neighborhood) != expected {
+				t.Errorf("Expected neighborhood %09b to become %d, got %d", neighborhood, expected, r.next(neighborhood))
+			}
+		}
+	})
+
+	t.Run("Hensel classes", func(t *testing.T) {
+		r, _ := ParseRule("B2-a/S")
+
+		// Two adjacent neighbors, north and north-east.
+		if r.next(0b000000110) != Dead {
+			t.Errorf("Expected no birth on 2a")
+		}
+
+		// Two opposite neighbors, north and south.
+		if r.next(0b010000010) != Alive {
+			t.Errorf("Expected birth on 2i")
+		}
+	})
+}
+
+func TestLookupRule(t *testing.T) {
+	r, err := LookupRule("Conway")
+	if err != nil || r.String() != "B3/S23" {
+		t.Errorf("Expected conway to be B3/S23, got %v, %v", r, err)
+	}
+
+	r, err = LookupRule("B36/S23")
+	if err != nil || r.String() != "B36/S23" {
+		t.Errorf("Expected rulestring to be parsed, got %v, %v", r, err)
+	}
+
+	if err := RegisterRule("test", "B9/S"); err != errInvalidRule {
+		t.Errorf("Expected error to be %v, got %v", errInvalidRule, err)
+	}
+
+	// Registering rules while soups run is safe, as checked by -race.
+	restoreRule(t, "concurrent")
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 20; i++ {
+			RegisterRule("concurrent", "B36/S23")
+		}
+		done <- true
+	}()
+	SweepSoups(1, 8, SoupOptions{Height: 8, Width: 8, Density: 40, MaxGenerations: 10})
+	<-done
+
+	if r, _ := LookupRule("concurrent"); r == nil || r.String() != "B36/S23" {
+		t.Errorf("Expected the concurrent rule to be registered, got %v", r)
+	}
+}
+
+func TestRegisteredRules(t *testing.T) {
+	t.Run("Cleanup", func(t *testing.T) {
+		restoreRule(t, "cleanup")
+		RegisterRule("cleanup", "B36/S23")
+	})
+
+	for _, name := range RegisteredRules() {
+		if name == "cleanup" || name == "concurrent" {
+			t.Errorf("Expected %s to be unregistered after its test", name)
+		}
+	}
+}
+
+func TestDefaultRule(t *testing.T) {
+	restoreRule(t, "conway")
+	if err := RegisterRule("conway", "B36/S23"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// B36/S23 would give birth to the center cell, which has 6 neighbors.
+	f := NewFigure([][]uint8{{Alive, Alive, Alive}, {Alive, Dead, Alive}, {Alive, Dead, Dead}})
+	if a := f.Advance(1, nil); a.String() != f.Advance(1, conwayRule).String() {
+		t.Errorf("Expected figures to advance under B3/S23 by default, got:\n%s", a)
+	}
+}
+
+// restoreRule registers the current rule of the given name again, or
+// unregisters it, when the test ends.
+func restoreRule(t *testing.T, name string) {
+	registryMu.RLock()
+	r, ok := registry[name]
+	registryMu.RUnlock()
+
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		if ok {
+			registry[name] = r
+		} else {
+			delete(registry, name)
+		}
+	})
+}
+
+func TestUniverseSetRule(t *testing.T) {
+	t.Run("Same as RulesFunc", func(t *testing.T) {
+		for name, rules := range map[string]func(u *Universe) func(uint8, uint32, uint32) uint8{
+			"conway":      func(u *Universe) func(uint8, uint32, uint32) uint8 { return u.ConwayRules },
+			"seeds":       func(u *Universe) func(uint8, uint32, uint32) uint8 { return u.SeedsRules },
+			"dayandnight": func(u *Universe) func(uint8, uint32, uint32) uint8 { return u.DayAndNightRules },
+			"rule30":      func(u *Universe) func(uint8, uint32, uint32) uint8 { return u.WolframRule30 },
+			"rule110":     func(u *Universe) func(uint8, uint32, uint32) uint8 { return u.WolframRule110 },
+		} {
+			u := NewUniverse(24, 32)
+			u.Randomize(40)
+			u.Rules = rules(u)
+
+			u2 := NewUniverse(24, 32)
+			u2.Parse(u.String())
+			r, _ := LookupRule(name)
+			u2.SetRule(r)
+
+			for i := 0; i < 10; i++ {
+				u.Tick()
+				u2.Tick()
+			}
+
+			if u.String() != u2.String() {
+				t.Errorf("Expected %s to match its Rules function:\n%s\ngot:\n%s", name, u, u2)
+			}
+		}
+	})
+
+	t.Run("Rules stays consistent", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		r, _ := LookupRule("conway")
+		u.SetRule(r)
+		u.SetRectangle(2, 2, [][]uint8{{Alive, Alive, Alive}})
+
+		if u.Rules(Dead, 1, 3) != Alive {
+			t.Errorf("Expected Rules to use the compiled rule")
+		}
+		if u.Rule() != r {
+			t.Errorf("Expected Rule to return %v, got %v", r, u.Rule())
+		}
+	})
+
+	t.Run("BoundaryWrap", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		r, _ := LookupRule("conway")
+		u.SetRule(r)
+		u.Boundary = BoundaryWrap
+
+		// A blinker crossing the left edge.
+		u.SetRectangle(4, 0, [][]uint8{{Alive, Alive}})
+		u.SetRectangle(4, 7, [][]uint8{{Alive}})
+		u.Tick()
+
+		for _, row := range []uint32{3, 4, 5} {
+			if u.Cell(u.GetIndex(row, 0)) != Alive {
+				t.Errorf("Expected cell (%d, 0) to be alive, got:\n%s", row, u)
+			}
+		}
+		if u.Cell(u.GetIndex(4, 7)) != Dead {
+			t.Errorf("Expected cell (4, 7) to be dead, got:\n%s", u)
+		}
+	})
+}
@@ -0,0 +1,296 @@
+package game
+
+import (
+	"context"
+	"time"
+)
+
+// Simulation is anything a Runner can advance one generation at a time,
+// such as a Universe, a DistributedUniverse, a History or a ParallelGroup.
+type Simulation interface {
+	Tick()
+	Stats() Stats
+	Stable() bool
+	Hash() uint64
+}
+
+// StopReason tells why a run ended.
+type StopReason uint8
+
+const (
+	// ReasonNone means the run has not ended yet.
+	ReasonNone StopReason = iota
+	// ReasonMaxGenerations means the run advanced as many generations as
+	// it was allowed to.
+	ReasonMaxGenerations
+	// ReasonCanceled means the context of the run was done.
+	ReasonCanceled
+	// ReasonExtinct means every cell died.
+	ReasonExtinct
+	// ReasonStable means no cell changed in the last generation.
+	ReasonStable
+	// ReasonPeriodic means the cells repeated an earlier state.
+	ReasonPeriodic
+	// ReasonPopulation means the population crossed a threshold.
+	ReasonPopulation
+	// ReasonBudget means the run took as long as it was allowed to.
+	ReasonBudget
+	// ReasonCondition means a condition given to StopWhen was met.
+	ReasonCondition
+)
+
+func (r StopReason) String() string {
+	switch r {
+	case ReasonMaxGenerations:
+		return "max generations"
+	case ReasonCanceled:
+		return "canceled"
+	case ReasonExtinct:
+		return "extinct"
+	case ReasonStable:
+		return "stable"
+	case ReasonPeriodic:
+		return "periodic"
+	case ReasonPopulation:
+		return "population"
+	case ReasonBudget:
+		return "budget"
+	case ReasonCondition:
+		return "condition"
+	default:
+		return "none"
+	}
+}
+
+// Step describes a generation reached by a run.
+type Step struct {
+	Stats
+	// Elapsed is the wall-clock time since the run started.
+	Elapsed time.Duration
+}
+
+// StopCondition ends a run when it is met after a generation.
+type StopCondition struct {
+	reason StopReason
+	// maxPeriod is the longest cycle looked for by StopWhenPeriodic.
+	maxPeriod uint32
+	met       func(run *Run, step Step) bool
+}
+
+// StopWhenExtinct stops a run once every cell is dead.
+func StopWhenExtinct() StopCondition {
+	return StopCondition{reason: ReasonExtinct, met: func(run *Run, step Step) bool {
+		return step.Population == 0
+	}}
+}
+
+// StopWhenStable stops a run once a generation leaves every cell unchanged.
+// See https://conwaylife.com/wiki/Still_life for more information.
+func StopWhenStable() StopCondition {
+	return StopCondition{reason: ReasonStable, met: func(run *Run, step Step) bool {
+		return run.simulation.Stable()
+	}}
+}
+
+// StopWhenPeriodic stops a run once the cells repeat a state from at most maxPeriod
+// generations before, which Result reports as a Cycle. States are compared
+// by their Hash.
+func StopWhenPeriodic(maxPeriod uint32) StopCondition {
+	return StopCondition{reason: ReasonPeriodic, maxPeriod: maxPeriod, met: func(run *Run, step Step) bool {
+		return run.cycles != nil && run.cycles.found && run.cycles.cycle.Period <= maxPeriod
+	}}
+}
+
+// StopAbovePopulation stops a run once more than n cells are alive.
+func StopAbovePopulation(n uint32) StopCondition {
+	return StopCondition{reason: ReasonPopulation, met: func(run *Run, step Step) bool {
+		return step.Population > n
+	}}
+}
+
+// StopBelowPopulation stops a run once fewer than n cells are alive.
+func StopBelowPopulation(n uint32) StopCondition {
+	return StopCondition{reason: ReasonPopulation, met: func(run *Run, step Step) bool {
+		return step.Population < n
+	}}
+}
+
+// StopAfter stops a run once it has been running for the given wall-clock time.
+func StopAfter(d time.Duration) StopCondition {
+	return StopCondition{reason: ReasonBudget, met: func(run *Run, step Step) bool {
+		return step.Elapsed >= d
+	}}
+}
+
+// StopWhen stops a run once fn returns true.
+func StopWhen(fn func(step Step) bool) StopCondition {
+	return StopCondition{reason: ReasonCondition, met: func(run *Run, step Step) bool {
+		return fn(step)
+	}}
+}
+
+// Result describes how a run ended.
+type Result struct {
+	Reason StopReason
+	// Generations is the number of generations the run advanced.
+	Generations uint32
+	// Last is the last generation reached.
+	Last Step
+	// Cycle is the cycle the cells entered, when the reason is
+	// ReasonPeriodic.
+	Cycle Cycle
+}
+
+// Runner advances simulations until they reach a maximum number of
+// generations or meet one of the stop conditions, calling the observers
+// after every generation.
+type Runner struct {
+	// MaxGenerations is the number of generations a run can advance, with
+	// no limit if it is 0.
+	MaxGenerations uint32
+	// Stop are the conditions checked after every generation, in order.
+	Stop []StopCondition
+	// Observers are called with every generation, in order, before the
+	// stop conditions are checked.
+	Observers []func(step Step)
+}
+
+// NewRunner returns a Runner that advances simulations for at most the given
+// number of generations, or with no limit if it is 0, and until any of the
+// stop conditions is met.
+func NewRunner(maxGenerations uint32, stop ...StopCondition) *Runner {
+	return &Runner{MaxGenerations: maxGenerations, Stop: stop}
+}
+
+// Observe adds an observer to be called after every generation.
+func (r *Runner) Observe(fn func(step Step)) *Runner {
+	r.Observers = append(r.Observers, fn)
+	return r
+}
+
+// Run advances the simulation until the run ends, and returns why. If the
+// context is done first, it also returns the error of the context.
+func (r *Runner) Run(ctx context.Context, s Simulation) (Result, error) {
+	run := r.Start(s)
+	for !run.Done() {
+		select {
+		case <-ctx.Done():
+			run.result.Reason = ReasonCanceled
+			return run.result, ctx.Err()
+		default:
+		}
+
+		run.Next()
+	}
+
+	return run.result, nil
+}
+
+// Steps advances the simulation in a new goroutine, sending every generation
+// on the first channel, which is closed when the run ends. The result is
+// then sent on the second channel. Canceling the context ends the run, even
+// if nobody receives the generations.
+func (r *Runner) Steps(ctx context.Context, s Simulation) (<-chan Step, <-chan Result) {
+	steps := make(chan Step)
+	results := make(chan Result, 1)
+
+	go func() {
+		defer close(results)
+
+		run := r.Start(s)
+		for !run.Done() {
+			select {
+			case <-ctx.Done():
+				run.result.Reason = ReasonCanceled
+				continue
+			default:
+			}
+
+			step := run.Next()
+			select {
+			case steps <- step:
+			case <-ctx.Done():
+				run.result.Reason = ReasonCanceled
+			}
+		}
+
+		close(steps)
+		results <- run.result
+	}()
+
+	return steps, results
+}
+
+// Run is a run of a Runner that is advanced by the caller, one generation
+// at a time, such as from an animation loop.
+type Run struct {
+	runner     *Runner
+	simulation Simulation
+	start      time.Time
+	cycles     *cycleDetector
+	result     Result
+}
+
+// Start begins a run of the simulation without advancing it.
+func (r *Runner) Start(s Simulation) *Run {
+	run := &Run{runner: r, simulation: s, start: time.Now()}
+	run.result.Last = Step{Stats: s.Stats()}
+
+	var maxPeriod uint32
+	for _, c := range r.Stop {
+		maxPeriod = max(maxPeriod, c.maxPeriod)
+	}
+	if maxPeriod > 0 {
+		run.cycles = newCycleDetector(maxPeriod)
+		run.cycles.observe(s.Stats().Generation, s.Hash())
+	}
+
+	return run
+}
+
+// Next advances the simulation by one generation and returns it, then
+// checks whether the run ended. Once the run ended, Next returns the last
+// generation without advancing.
+func (run *Run) Next() Step {
+	if run.Done() {
+		return run.result.Last
+	}
+
+	r := run.runner
+	run.simulation.Tick()
+	step := Step{Stats: run.simulation.Stats(), Elapsed: time.Since(run.start)}
+	run.result.Generations++
+	run.result.Last = step
+	if run.cycles != nil {
+		run.cycles.observe(step.Generation, run.simulation.Hash())
+	}
+
+	for _, fn := range r.Observers {
+		fn(step)
+	}
+
+	for _, c := range r.Stop {
+		if c.met(run, step) {
+			run.result.Reason = c.reason
+			if c.reason == ReasonPeriodic {
+				run.result.Cycle = run.cycles.cycle
+			}
+			return step
+		}
+	}
+	if r.MaxGenerations > 0 && run.result.Generations >= r.MaxGenerations {
+		run.result.Reason = ReasonMaxGenerations
+	}
+
+	return step
+}
+
+// Done reports whether the run ended.
+func (run *Run) Done() bool {
+	return run.result.Reason != ReasonNone
+}
+
+// Result returns how the run ended, or ReasonNone while it goes on.
+func (run *Run) Result() Result {
+	return run.result
+}
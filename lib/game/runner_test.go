@@ -0,0 +1,200 @@
+package game
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRunner(t *testing.T) {
+	blinker := [][]uint8{{Alive, Alive, Alive}}
+
+	t.Run("Stop conditions", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			values      [][]uint8
+			runner      *Runner
+			reason      StopReason
+			generations uint32
+		}{
+			{"Max generations", Glider().Values(), NewRunner(10), ReasonMaxGenerations, 10},
+			{"Extinct", [][]uint8{{Alive, Alive}}, NewRunner(10, StopWhenExtinct()), ReasonExtinct, 1},
+			{"Stable", Beehive().Values(), NewRunner(10, StopWhenStable()), ReasonStable, 1},
+			{"Periodic", blinker, NewRunner(10, StopWhenPeriodic(4)), ReasonPeriodic, 2},
+			{"Above", [][]uint8{{Alive, Alive, Alive, Alive}}, NewRunner(10, StopAbovePopulation(4)), ReasonPopulation, 1},
+			{"Below", blinker, NewRunner(10, StopBelowPopulation(3)), ReasonMaxGenerations, 10},
+			{"Budget", Glider().Values(), NewRunner(10, StopAfter(0)), ReasonBudget, 1},
+			{"Condition", Glider().Values(), NewRunner(0, StopWhen(func(s Step) bool { return s.Generation == 7 })), ReasonCondition, 7},
+		}
+
+		for _, tc := range cases {
+			u := NewUniverse(16, 16)
+			u.SetRectangle(4, 4, tc.values)
+
+			result, err := tc.runner.Run(context.Background(), u)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result.Reason != tc.reason || result.Generations != tc.generations {
+				t.Errorf("%s: expected %s after %d generations, got %s after %d",
+					tc.name, tc.reason, tc.generations, result.Reason, result.Generations)
+			}
+			if result.Last.Generation != u.Generation {
+				t.Errorf("%s: expected the last step to be generation %d, got %d", tc.name, u.Generation, result.Last.Generation)
+			}
+		}
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		u.Generation = 5
+		u.SetRectangle(3, 2, blinker)
+
+		result, _ := NewRunner(0, StopWhenPeriodic(8)).Run(context.Background(), u)
+		if result.Cycle != (Cycle{Start: 5, Period: 2}) {
+			t.Errorf("Expected a cycle of period 2 from generation 5, got %+v", result.Cycle)
+		}
+	})
+
+	t.Run("Observers", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.SetRectangle(4, 4, Glider().Values())
+
+		var generations []uint32
+		var populations []uint32
+		runner := NewRunner(4).
+			Observe(func(s Step) { generations = append(generations, s.Generation) }).
+			Observe(func(s Step) { populations = append(populations, s.Population) })
+		runner.Run(context.Background(), u)
+
+		if len(generations) != 4 || generations[0] != 1 || generations[3] != 4 {
+			t.Errorf("Expected generations 1 to 4, got %v", generations)
+		}
+		for _, p := range populations {
+			if p != 5 {
+				t.Errorf("Expected a population of 5, got %v", populations)
+				break
+			}
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		u := NewUniverse(16, 16)
+		u.SetRectangle(4, 4, blinker)
+
+		runner := NewRunner(0).Observe(func(s Step) {
+			if s.Generation == 3 {
+				cancel()
+			}
+		})
+		result, err := runner.Run(ctx, u)
+		if err != context.Canceled || result.Reason != ReasonCanceled || result.Generations != 3 {
+			t.Errorf("Expected to be canceled after 3 generations, got %v, %s after %d", err, result.Reason, result.Generations)
+		}
+	})
+
+	t.Run("Steps", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.SetRectangle(4, 4, Glider().Values())
+
+		steps, results := NewRunner(6).Steps(context.Background(), u)
+		count := 0
+		for step := range steps {
+			count++
+			if step.Generation != uint32(count) {
+				t.Errorf("Expected generation %d, got %d", count, step.Generation)
+			}
+		}
+
+		result := <-results
+		if count != 6 || result.Reason != ReasonMaxGenerations {
+			t.Errorf("Expected 6 steps, got %d and %s", count, result.Reason)
+		}
+	})
+
+	t.Run("Steps canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		steps, results := NewRunner(0).Steps(ctx, NewUniverse(8, 8))
+		<-steps
+		cancel()
+
+		if result := <-results; result.Reason != ReasonCanceled {
+			t.Errorf("Expected the run to be canceled, got %s", result.Reason)
+		}
+
+		// Steps are still received after the cancellation, which must not
+		// advance the universe any further.
+		ctx, cancel = context.WithCancel(context.Background())
+		u := NewUniverse(8, 8)
+		runner := NewRunner(0).Observe(func(s Step) {
+			if s.Generation == 3 {
+				cancel()
+			}
+		})
+		steps, results = runner.Steps(ctx, u)
+		for range steps {
+		}
+
+		if result := <-results; result.Reason != ReasonCanceled || result.Last.Generation != 3 || u.Generation != 3 {
+			t.Errorf("Expected the run to be canceled at generation 3, got %s at %d", result.Reason, u.Generation)
+		}
+	})
+
+	t.Run("Step by step", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		u.SetRectangle(3, 2, blinker)
+
+		run := NewRunner(0, StopWhenPeriodic(4)).Start(u)
+		run.Next()
+		if run.Done() {
+			t.Errorf("Expected the run to go on after 1 generation")
+		}
+		run.Next()
+		if !run.Done() || run.Result().Reason != ReasonPeriodic {
+			t.Errorf("Expected the run to end after 2 generations, got %s", run.Result().Reason)
+		}
+		if step := run.Next(); step.Generation != 2 || u.Generation != 2 {
+			t.Errorf("Expected an ended run not to advance, got generation %d", step.Generation)
+		}
+	})
+
+	t.Run("Distributed", func(t *testing.T) {
+		d := NewDistributedUniverse(GenerateKey(), 16, 16)
+		d.SetRectangle(4, 4, blinker)
+
+		result, _ := NewRunner(10, StopWhenPeriodic(4)).Run(context.Background(), d)
+		if result.Reason != ReasonPeriodic || result.Cycle.Period != 2 {
+			t.Errorf("Expected the blinker to oscillate, got %s with %+v", result.Reason, result.Cycle)
+		}
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		left, right := NewParallelUniverse(8, 8), NewParallelUniverse(8, 8)
+		left.SetRightNeighbor(right)
+		right.SetLeftNeighbor(left)
+		// A blinker across the edge only oscillates if both halves see
+		// each other.
+		left.SetRectangle(3, 6, [][]uint8{{Alive, Alive}})
+		right.SetRectangle(3, 0, [][]uint8{{Alive}})
+
+		group := ParallelGroup{left, right}
+		result, _ := NewRunner(10, StopWhenPeriodic(4)).Run(context.Background(), group)
+		if result.Reason != ReasonPeriodic || result.Cycle.Period != 2 || result.Last.Population != 3 {
+			t.Errorf("Expected the blinker to oscillate, got %s with %+v", result.Reason, result.Last)
+		}
+		if left.Generation != right.Generation || left.Generation != result.Last.Generation {
+			t.Errorf("Expected the universes to advance together, got %d and %d", left.Generation, right.Generation)
+		}
+	})
+
+	t.Run("History", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		h := NewHistory(u, 2)
+		h.SetRectangle(3, 2, blinker)
+		NewRunner(5).Run(context.Background(), h)
+
+		if err := h.SeekGeneration(2); err != nil || u.Generation != 2 {
+			t.Errorf("Expected the history to record the run, got %v", err)
+		}
+	})
+}
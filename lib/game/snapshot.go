@@ -0,0 +1,228 @@
+package game
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+)
+
+// A snapshot is laid out as follows, with integers in big-endian order:
+//
+//	magic      [4]byte "VITA"
+//	version    uint16
+//	kind       uint8   snapshotUniverse or snapshotDistributed
+//	height     uint32
+//	width      uint32
+//	generation uint32
+//	boundary   uint8
+//	rule       uint16 length, then the rulestring, empty without a compiled rule
+//	ids        [5][IDLength]byte, only for snapshotDistributed
+//	cells      uint32 length, then the cells compressed with DEFLATE
+//	checksum   uint32 CRC-32 (IEEE) of everything before it
+const (
+	snapshotMagic   = "VITA"
+	snapshotVersion = 1
+
+	snapshotUniverse    = 0
+	snapshotDistributed = 1
+)
+
+// MarshalBinary returns a self-describing snapshot of the universe, with
+// its size, generation, boundary, compiled rule and compressed cells.
+func (u *Universe) MarshalBinary() ([]byte, error) {
+	return u.snapshot(snapshotUniverse, nil)
+}
+
+// UnmarshalBinary restores a snapshot made by MarshalBinary. The snapshot
+// must have the same size as the universe, see LoadSnapshot otherwise.
+func (u *Universe) UnmarshalBinary(data []byte) error {
+	s, err := parseSnapshot(data, snapshotUniverse)
+	if err != nil {
+		return err
+	}
+
+	return s.restore(u)
+}
+
+// MarshalBinary returns a self-describing snapshot of the universe like
+// Universe.MarshalBinary, along with the IDs of the universe and of its
+// neighbors.
+func (d *DistributedUniverse) MarshalBinary() ([]byte, error) {
+	return d.snapshot(snapshotDistributed, []string{d.ID, d.TopID, d.BottomID, d.LeftID, d.RightID})
+}
+
+// UnmarshalBinary restores a snapshot made by DistributedUniverse.MarshalBinary.
+// The snapshot must have the same size as the universe.
+func (d *DistributedUniverse) UnmarshalBinary(data []byte) error {
+	s, err := parseSnapshot(data, snapshotDistributed)
+	if err != nil {
+		return err
+	}
+	if err := s.restore(d.Universe); err != nil {
+		return err
+	}
+
+	d.ID, d.TopID, d.BottomID, d.LeftID, d.RightID = s.ids[0], s.ids[1], s.ids[2], s.ids[3], s.ids[4]
+	return nil
+}
+
+// LoadSnapshot returns a new universe of the size stored in a snapshot made
+// by Universe.MarshalBinary.
+func LoadSnapshot(data []byte) (*Universe, error) {
+	s, err := parseSnapshot(data, snapshotUniverse)
+	if err != nil {
+		return nil, err
+	}
+
+	u := NewUniverse(s.height, s.width)
+	if err := s.restore(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func (u *Universe) snapshot(kind uint8, ids []string) ([]byte, error) {
+	var rulestring string
+	if u.rule != nil {
+		rulestring = u.rule.String()
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(snapshotMagic)
+	binary.Write(buf, binary.BigEndian, uint16(snapshotVersion))
+	buf.WriteByte(kind)
+	binary.Write(buf, binary.BigEndian, []uint32{u.height, u.width, u.Generation})
+	buf.WriteByte(uint8(u.Boundary))
+	binary.Write(buf, binary.BigEndian, uint16(len(rulestring)))
+	buf.WriteString(rulestring)
+	for _, id := range ids {
+		padded := make([]byte, IDLength)
+		copy(padded, id)
+		buf.Write(padded)
+	}
+
+	cells := &bytes.Buffer{}
+	w, err := flate.NewWriter(cells, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(u.cells); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	binary.Write(buf, binary.BigEndian, uint32(cells.Len()))
+	buf.Write(cells.Bytes())
+
+	binary.Write(buf, binary.BigEndian, crc32.ChecksumIEEE(buf.Bytes()))
+	return buf.Bytes(), nil
+}
+
+// snapshot is the decoded content of a snapshot.
+type snapshot struct {
+	height, width, generation uint32
+	boundary                  Boundary
+	rule                      *Rule
+	ids                       []string
+	cells                     []uint8
+}
+
+func parseSnapshot(data []byte, kind uint8) (*snapshot, error) {
+	if len(data) < len(snapshotMagic) || string(data[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, ErrSnapshotMagic
+	}
+	if len(data) < len(snapshotMagic)+2+4 {
+		return nil, ErrSnapshotCorrupt
+	}
+
+	body, checksum := data[:len(data)-4], binary.BigEndian.Uint32(data[len(data)-4:])
+	r := bytes.NewReader(body[len(snapshotMagic):])
+	var version uint16
+	binary.Read(r, binary.BigEndian, &version)
+	if version != snapshotVersion {
+		return nil, ErrSnapshotVersion
+	}
+	if crc32.ChecksumIEEE(body) != checksum {
+		return nil, ErrSnapshotCorrupt
+	}
+
+	s := &snapshot{}
+	var header struct {
+		Kind                      uint8
+		Height, Width, Generation uint32
+		Boundary                  uint8
+		RuleLength                uint16
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, ErrSnapshotCorrupt
+	}
+	if header.Kind != kind {
+		return nil, ErrSnapshotKind
+	}
+	if uint64(header.Height)*uint64(header.Width) > maxDecodedCells {
+		return nil, errInvalidLength
+	}
+	s.height, s.width, s.generation = header.Height, header.Width, header.Generation
+	s.boundary = Boundary(header.Boundary)
+	if s.boundary != BoundaryDead && s.boundary != BoundaryWrap {
+		return nil, errInvalidBoundary
+	}
+
+	rulestring := make([]byte, header.RuleLength)
+	if _, err := io.ReadFull(r, rulestring); err != nil {
+		return nil, ErrSnapshotCorrupt
+	}
+	if len(rulestring) > 0 {
+		var err error
+		if s.rule, err = LookupRule(string(rulestring)); err != nil {
+			return nil, ErrSnapshotCorrupt
+		}
+	}
+
+	if kind == snapshotDistributed {
+		id := make([]byte, IDLength)
+		for i := 0; i < 5; i++ {
+			if _, err := io.ReadFull(r, id); err != nil {
+				return nil, ErrSnapshotCorrupt
+			}
+			s.ids = append(s.ids, string(id))
+		}
+	}
+
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil || int(length) != r.Len() {
+		return nil, ErrSnapshotCorrupt
+	}
+	// One byte past the cells is enough to tell that there are too many.
+	s.cells = make([]uint8, s.height*s.width)
+	cells := io.LimitReader(flate.NewReader(r), int64(len(s.cells))+1)
+	if _, err := io.ReadFull(cells, s.cells); err != nil {
+		return nil, ErrSnapshotCorrupt
+	}
+	if n, _ := cells.Read(make([]byte, 1)); n > 0 {
+		return nil, ErrSnapshotCorrupt
+	}
+
+	return s, nil
+}
+
+// restore sets the state of the universe to the snapshot.
+func (s *snapshot) restore(u *Universe) error {
+	if s.height != u.height || s.width != u.width {
+		return &SnapshotSizeError{Height: s.height, Width: s.width, WantHeight: u.height, WantWidth: u.width}
+	}
+
+	copy(u.cells, s.cells)
+	u.Generation = s.generation
+	u.Boundary = s.boundary
+	if s.rule != nil {
+		u.SetRule(s.rule)
+	}
+	u.changed()
+
+	return nil
+}
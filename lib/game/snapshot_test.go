@@ -0,0 +1,119 @@
+package game
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		u := NewUniverse(24, 40)
+		r, _ := LookupRule("highlife")
+		u.SetRule(r)
+		u.Boundary = BoundaryWrap
+		u.Randomize(40)
+		u.Tick()
+
+		data, err := u.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		u2, err := LoadSnapshot(data)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if u2.String() != u.String() || u2.Generation != 1 || u2.Boundary != BoundaryWrap {
+			t.Errorf("Expected the universe to be restored, got generation %d, boundary %d:\n%s", u2.Generation, u2.Boundary, u2)
+		}
+		if u2.Rule() == nil || u2.Rule().String() != "B36/S23" {
+			t.Errorf("Expected rule to be B36/S23, got %v", u2.Rule())
+		}
+		if u2.Population() != u.Population() {
+			t.Errorf("Expected population %d, got %d", u.Population(), u2.Population())
+		}
+	})
+
+	t.Run("Compressed", func(t *testing.T) {
+		u := NewUniverse(256, 256)
+		u.SetRectangle(100, 100, Glider().Values())
+
+		data, _ := u.MarshalBinary()
+		if len(data) > 256 {
+			t.Errorf("Expected an almost empty universe to compress, got %d bytes", len(data))
+		}
+	})
+
+	t.Run("Size mismatch", func(t *testing.T) {
+		data, _ := NewUniverse(8, 8).MarshalBinary()
+
+		err := NewUniverse(8, 16).UnmarshalBinary(data)
+		var sizeErr *SnapshotSizeError
+		if !errors.As(err, &sizeErr) || sizeErr.Width != 8 || sizeErr.WantWidth != 16 {
+			t.Errorf("Expected a SnapshotSizeError, got %v", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		data, _ := NewUniverse(8, 8).MarshalBinary()
+
+		corrupted := append([]byte{}, data...)
+		corrupted[20] ^= 0xff
+		version := append([]byte{}, data...)
+		version[5] = 2
+		distributed, _ := NewDistributedUniverse(NullID, 8, 8).MarshalBinary()
+		// The header is checked before the cells are decompressed, so the
+		// checksum is enough to make these pass as valid.
+		huge := append([]byte{}, data...)
+		binary.BigEndian.PutUint32(huge[7:], 50000)
+		binary.BigEndian.PutUint32(huge[11:], 50000)
+		boundary := append([]byte{}, data...)
+		boundary[19] = 7
+		for _, d := range [][]byte{huge, boundary} {
+			binary.BigEndian.PutUint32(d[len(d)-4:], crc32.ChecksumIEEE(d[:len(d)-4]))
+		}
+
+		for name, test := range map[string]struct {
+			data     []byte
+			expected error
+		}{
+			"Empty":       {nil, ErrSnapshotMagic},
+			"Magic":       {[]byte("NOPE" + string(data[4:])), ErrSnapshotMagic},
+			"Truncated":   {data[:len(data)-10], ErrSnapshotCorrupt},
+			"Corrupted":   {corrupted, ErrSnapshotCorrupt},
+			"Version":     {version, ErrSnapshotVersion},
+			"Distributed": {distributed, ErrSnapshotKind},
+			"Huge":        {huge, errInvalidLength},
+			"Boundary":    {boundary, errInvalidBoundary},
+		} {
+			if err := NewUniverse(8, 8).UnmarshalBinary(test.data); err != test.expected {
+				t.Errorf("Expected %s snapshot to return %v, got %v", name, test.expected, err)
+			}
+		}
+	})
+}
+
+func TestDistributedSnapshot(t *testing.T) {
+	d := NewDistributedUniverse(GenerateKey(), 16, 16)
+	d.SetTopNeighbor(GenerateKey())
+	d.Randomize(50)
+
+	data, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	d2 := NewDistributedUniverse(NullID, 16, 16)
+	if err := d2.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if d2.ID != d.ID || d2.TopID != d.TopID || d2.BottomID != NullID || d2.String() != d.String() {
+		t.Errorf("Expected the universe to be restored, got %s, %s:\n%s", d2.ID, d2.TopID, d2)
+	}
+
+	if err := NewUniverse(16, 16).UnmarshalBinary(data); err != ErrSnapshotKind {
+		t.Errorf("Expected error to be %v, got %v", ErrSnapshotKind, err)
+	}
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// defaultSoupPeriod is the longest cycle detected when SoupOptions does not
+// set one. Most soups settle into oscillators of period 2 or 3, with some
+// of period 15 or 30.
+const defaultSoupPeriod = 30
+
+// SoupOptions configures the soups run by RunSoup and SweepSoups.
+type SoupOptions struct {
+	Height, Width uint32
+	// Density is the percentage of live cells, as in Randomize.
+	Density int
+	// Symmetry of the soup. SymmetryC4 and SymmetryD8 need a square
+	// universe.
+	Symmetry Symmetry
+	Rule     *Rule
+	Boundary Boundary
+	// MaxGenerations is how long a soup can run before giving up.
+	MaxGenerations uint32
+	// MaxPeriod is the longest cycle that counts as having stabilized.
+	MaxPeriod uint32
+}
+
+// SoupResult describes the life of a soup.
+type SoupResult struct {
+	Seed int64 `json:"seed"`
+	// Lifespan is the generation at which the soup entered a cycle, or the
+	// maximum number of generations if it did not stabilize.
+	Lifespan   uint32 `json:"lifespan"`
+	Stabilized bool   `json:"stabilized"`
+	// Period is the period of the final cycle, if the soup stabilized.
+	Period          uint32 `json:"period"`
+	FinalPopulation uint32 `json:"finalPopulation"`
+	PeakPopulation  uint32 `json:"peakPopulation"`
+	PeakGeneration  uint32 `json:"peakGeneration"`
+}
+
+// RunSoup fills a universe with random cells from the given seed, then
+// evolves it until it stabilizes or reaches the maximum number of
+// generations. The same seed and options always produce the same result.
+// It returns an error if the options cannot produce a soup, such as a
+// symmetry the universe cannot have.
+// See https://conwaylife.com/wiki/Methuselah for more information.
+func RunSoup(seed int64, opts SoupOptions) (SoupResult, error) {
+	if err := opts.validate(); err != nil {
+		return SoupResult{}, err
+	}
+
+	u := NewUniverse(opts.Height, opts.Width)
+	if opts.Rule != nil {
+		u.SetRule(opts.Rule)
+	} else {
+		u.SetRule(conwayRule)
+	}
+	u.Boundary = opts.Boundary
+	err := u.RandomizeWith(RandomOptions{
+		Rand:     rand.New(rand.NewSource(seed)),
+		Density:  min(max(opts.Density, 0), 100),
+		Symmetry: opts.Symmetry,
+	})
+	if err != nil {
+		return SoupResult{}, err
+	}
+
+	maxPeriod := opts.MaxPeriod
+	if maxPeriod == 0 {
+		maxPeriod = defaultSoupPeriod
+	}
+
+	result := SoupResult{Seed: seed, PeakPopulation: u.Population()}
+	if opts.MaxGenerations > 0 {
+		runner := NewRunner(opts.MaxGenerations, StopWhenPeriodic(maxPeriod))
+		runner.Observe(func(step Step) {
+			if step.Population > result.PeakPopulation {
+				result.PeakPopulation = step.Population
+				result.PeakGeneration = step.Generation
+			}
+		})
+
+		if run, _ := runner.Run(context.Background(), u); run.Reason == ReasonPeriodic {
+			result.Stabilized = true
+			result.Lifespan = run.Cycle.Start
+			result.Period = run.Cycle.Period
+		}
+	}
+
+	if !result.Stabilized {
+		result.Lifespan = u.Generation
+	}
+	result.FinalPopulation = u.Population()
+	return result, nil
+}
+
+// SweepSoups runs count soups with consecutive seeds starting from first,
+// spread over as many goroutines as there are CPUs. The results are in
+// the same order as the seeds, and there are none if count is not
+// positive. It returns an error, without running any soup, if the options
+// cannot produce one.
+func SweepSoups(first int64, count int, opts SoupOptions) ([]SoupResult, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	count = max(count, 0)
+	results := make([]SoupResult, count)
+	seeds := make(chan int, count)
+	for i := 0; i < count; i++ {
+		seeds <- i
+	}
+	close(seeds)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.NumCPU(), count); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range seeds {
+				// The options were validated, so no soup can fail.
+				results[i], _ = RunSoup(first+int64(i), opts)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// validate returns an error if the options cannot produce a soup.
+func (opts SoupOptions) validate() error {
+	if opts.Symmetry > SymmetryD8 {
+		return errInvalidSymmetry
+	}
+	if (opts.Symmetry == SymmetryC4 || opts.Symmetry == SymmetryD8) && opts.Height != opts.Width {
+		return errInvalidRegion
+	}
+
+	return nil
+}
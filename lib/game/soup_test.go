@@ -0,0 +1,83 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestRunSoup(t *testing.T) {
+	opts := SoupOptions{Height: 32, Width: 32, Density: 40, MaxGenerations: 5000}
+
+	t.Run("Deterministic", func(t *testing.T) {
+		result, err := RunSoup(42, opts)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if again, _ := RunSoup(42, opts); result != again {
+			t.Errorf("Expected the same seed to give the same result")
+		}
+		if result.Seed != 42 {
+			t.Errorf("Expected seed to be 42, got %d", result.Seed)
+		}
+		if !result.Stabilized || result.Period == 0 {
+			t.Errorf("Expected soup to stabilize, got %+v", result)
+		}
+		if result.PeakPopulation < result.FinalPopulation || result.PeakGeneration > result.Lifespan {
+			t.Errorf("Expected peak to come before the end, got %+v", result)
+		}
+	})
+
+	t.Run("MaxGenerations", func(t *testing.T) {
+		short := opts
+		short.MaxGenerations = 3
+
+		result, _ := RunSoup(42, short)
+		if result.Stabilized || result.Lifespan != 3 {
+			t.Errorf("Expected soup to stop at generation 3, got %+v", result)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		empty := opts
+		empty.Density = 0
+
+		result, _ := RunSoup(1, empty)
+		if !result.Stabilized || result.Lifespan != 0 || result.FinalPopulation != 0 {
+			t.Errorf("Expected an empty soup to be stable at once, got %+v", result)
+		}
+	})
+
+	t.Run("Invalid symmetry", func(t *testing.T) {
+		symmetric := opts
+		symmetric.Height, symmetric.Width, symmetric.Symmetry = 10, 20, SymmetryC4
+
+		if _, err := RunSoup(1, symmetric); err != errInvalidRegion {
+			t.Errorf("Expected error to be %v, got %v", errInvalidRegion, err)
+		}
+		if _, err := SweepSoups(1, 4, symmetric); err != errInvalidRegion {
+			t.Errorf("Expected error to be %v, got %v", errInvalidRegion, err)
+		}
+
+		symmetric.Symmetry = 9
+		if _, err := RunSoup(1, symmetric); err != errInvalidSymmetry {
+			t.Errorf("Expected error to be %v, got %v", errInvalidSymmetry, err)
+		}
+	})
+}
+
+func TestSweepSoups(t *testing.T) {
+	opts := SoupOptions{Height: 16, Width: 16, Density: 50, MaxGenerations: 1000}
+
+	results, err := SweepSoups(100, 20, opts)
+	if err != nil || len(results) != 20 {
+		t.Fatalf("Expected 20 results, got %d and %v", len(results), err)
+	}
+	for i, result := range results {
+		if expected, _ := RunSoup(100+int64(i), opts); result != expected {
+			t.Errorf("Expected result %d to match RunSoup, got %+v", i, result)
+		}
+	}
+
+	if results, err := SweepSoups(100, -3, opts); err != nil || len(results) != 0 {
+		t.Errorf("Expected no results for a negative count, got %d and %v", len(results), err)
+	}
+}
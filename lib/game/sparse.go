@@ -0,0 +1,167 @@
+package game
+
+import (
+	"math"
+	"sort"
+)
+
+// Position is the row and column of a cell of a SparseUniverse.
+type Position struct {
+	Row, Column int64
+}
+
+// SparseUniverse is an unbounded universe that only stores its live cells,
+// for patterns that are too large or too spread out for a Universe.
+type SparseUniverse struct {
+	Generation uint32
+
+	cells map[Position]uint8
+	rule  *Rule
+}
+
+// NewSparseUniverse returns an empty unbounded universe evolving under the
+// given rule, or B3/S23 if rule is nil. Rules where dead cells are born
+// without live neighbors would fill the whole plane, so they are rejected.
+func NewSparseUniverse(rule *Rule) (*SparseUniverse, error) {
+	if rule == nil {
+		rule = conwayRule
+	}
+	if rule.next(0) != Dead {
+		return nil, errInvalidRule
+	}
+
+	return &SparseUniverse{cells: map[Position]uint8{}, rule: rule}, nil
+}
+
+// Sparse returns an unbounded copy of the live cells and rule of the
+// universe, with the top-left cell at the origin.
+func (u *Universe) Sparse() (*SparseUniverse, error) {
+	s, err := NewSparseUniverse(u.rule)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Generation = u.Generation
+	s.SetFigure(0, 0, &Figure{values: u.rows()})
+	return s, nil
+}
+
+// Rule returns the rule of the universe.
+func (s *SparseUniverse) Rule() *Rule {
+	return s.rule
+}
+
+// Cell returns the state of the cell at the given position.
+func (s *SparseUniverse) Cell(row, column int64) uint8 {
+	return s.cells[Position{row, column}]
+}
+
+// SetCell sets the state of the cell at the given position.
+func (s *SparseUniverse) SetCell(row, column int64, state uint8) {
+	if state == Dead {
+		delete(s.cells, Position{row, column})
+	} else {
+		s.cells[Position{row, column}] = state
+	}
+}
+
+// SetFigure sets the cells of the figure with its top-left corner at the
+// given position. Dead cells of the figure are left unchanged.
+func (s *SparseUniverse) SetFigure(row, column int64, f *Figure) {
+	for r, values := range f.values {
+		for c, cell := range values {
+			if cell != Dead {
+				s.SetCell(row+int64(r), column+int64(c), cell)
+			}
+		}
+	}
+}
+
+// Population returns the number of live cells.
+func (s *SparseUniverse) Population() int {
+	return len(s.cells)
+}
+
+// Cells returns the positions of the live cells, sorted by row and column.
+func (s *SparseUniverse) Cells() []Position {
+	positions := make([]Position, 0, len(s.cells))
+	for p := range s.cells {
+		positions = append(positions, p)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Row != positions[j].Row {
+			return positions[i].Row < positions[j].Row
+		}
+		return positions[i].Column < positions[j].Column
+	})
+
+	return positions
+}
+
+// Bounds returns the smallest rectangle containing all live cells, as its
+// top-left corner and its size. It returns false if there are none.
+func (s *SparseUniverse) Bounds() (row, column, height, width int64, ok bool) {
+	if len(s.cells) == 0 {
+		return 0, 0, 0, 0, false
+	}
+
+	top, left := int64(math.MaxInt64), int64(math.MaxInt64)
+	bottom, right := int64(math.MinInt64), int64(math.MinInt64)
+	for p := range s.cells {
+		top, left = min(top, p.Row), min(left, p.Column)
+		bottom, right = max(bottom, p.Row), max(right, p.Column)
+	}
+
+	return top, left, bottom - top + 1, right - left + 1, true
+}
+
+// Figure returns the live cells within their bounding box, and the position
+// of its top-left corner.
+func (s *SparseUniverse) Figure() (f *Figure, row, column int64) {
+	row, column, height, width, ok := s.Bounds()
+	if !ok {
+		return NewFigure(nil), 0, 0
+	}
+
+	values := make([][]uint8, height)
+	for r := range values {
+		values[r] = make([]uint8, width)
+	}
+	for p, cell := range s.cells {
+		values[p.Row-row][p.Column-column] = cell
+	}
+
+	return NewFigure(values), row, column
+}
+
+// Tick evolves the universe by one generation. Only the live cells and
+// their neighbors are visited.
+func (s *SparseUniverse) Tick() {
+	neighborhoods := make(map[Position]uint16, len(s.cells)*4)
+	for p := range s.cells {
+		if s.rule.elementary {
+			// Each row is a separate one-dimensional automaton.
+			neighborhoods[Position{p.Row, p.Column + 1}] |= 1 << 2
+			neighborhoods[p] |= 1 << 1
+			neighborhoods[Position{p.Row, p.Column - 1}] |= 1
+			continue
+		}
+
+		for dr := int64(-1); dr <= 1; dr++ {
+			for dc := int64(-1); dc <= 1; dc++ {
+				// The cell is at (-dr, -dc) from its neighbor.
+				neighborhoods[Position{p.Row + dr, p.Column + dc}] |= 1 << ((1-dr)*3 + 1 - dc)
+			}
+		}
+	}
+
+	cells := make(map[Position]uint8, len(s.cells))
+	for p, neighborhood := range neighborhoods {
+		if cell := s.rule.next(neighborhood); cell != Dead {
+			cells[p] = cell
+		}
+	}
+
+	s.cells = cells
+	s.Generation++
+}
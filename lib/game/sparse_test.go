@@ -0,0 +1,55 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestSparseUniverse(t *testing.T) {
+	t.Run("Glider", func(t *testing.T) {
+		s, _ := NewSparseUniverse(nil)
+		s.SetFigure(-1, -1, Glider())
+		for i := 0; i < 4; i++ {
+			s.Tick()
+		}
+
+		f, row, column := s.Figure()
+		if f.String() != Glider().String() || row != 0 || column != 0 {
+			t.Errorf("Expected the glider at (0, 0), got (%d, %d):\n%s", row, column, f)
+		}
+		if s.Generation != 4 || s.Population() != 5 {
+			t.Errorf("Expected generation 4 and population 5, got %d and %d", s.Generation, s.Population())
+		}
+	})
+
+	t.Run("Same as Universe", func(t *testing.T) {
+		for _, name := range []string{"conway", "highlife", "rule30"} {
+			r, _ := LookupRule(name)
+			u := NewUniverse(128, 128)
+			u.SetRule(r)
+			u.SetRectangle(63, 63, [][]uint8{{Dead, Alive, Alive}, {Alive, Alive, Dead}, {Dead, Alive, Dead}})
+
+			s, err := u.Sparse()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			for i := 0; i < 40; i++ {
+				u.Tick()
+				s.Tick()
+			}
+
+			values, row, column := trimValues(u.rows())
+			f, row2, column2 := s.Figure()
+			if f.String() != NewFigure(values).String() || int64(row) != row2 || int64(column) != column2 {
+				t.Errorf("Expected %s to match Universe at (%d, %d):\n%s\ngot (%d, %d):\n%s",
+					name, row, column, NewFigure(values), row2, column2, f)
+			}
+		}
+	})
+
+	t.Run("Invalid rule", func(t *testing.T) {
+		r, _ := ParseRule("B0/S8")
+		if _, err := NewSparseUniverse(r); err != errInvalidRule {
+			t.Errorf("Expected error to be %v, got %v", errInvalidRule, err)
+		}
+	})
+}
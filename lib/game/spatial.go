@@ -0,0 +1,124 @@
+package game
+
+import (
+	"math"
+)
+
+// BoundingBox returns the smallest rectangle that contains all the cells
+// that are not dead. It returns false if the universe is dead.
+func (u *Universe) BoundingBox() (Rect, bool) {
+	top, left, bottom, right := -1, -1, -1, -1
+	for row := 0; row < int(u.height); row++ {
+		for column := 0; column < int(u.width); column++ {
+			if u.cells[row*int(u.width)+column] == Dead {
+				continue
+			}
+			if top < 0 {
+				top = row
+			}
+			if left < 0 || column < left {
+				left = column
+			}
+			bottom = row
+			right = max(right, column)
+		}
+	}
+	if top < 0 {
+		return Rect{}, false
+	}
+
+	return Rect{Row: top, Column: left, Height: bottom - top + 1, Width: right - left + 1}, true
+}
+
+// Centroid returns the average position of the cells that are not dead.
+// It returns false if the universe is dead.
+func (u *Universe) Centroid() (row, column float64, ok bool) {
+	var count float64
+	for i, cell := range u.cells {
+		if cell == Dead {
+			continue
+		}
+		row += float64(i / int(u.width))
+		column += float64(i % int(u.width))
+		count++
+	}
+	if count == 0 {
+		return 0, 0, false
+	}
+
+	return row / count, column / count, true
+}
+
+// Density splits the universe into regions of the given size and returns
+// the fraction of cells that are not dead in each of them, row by row.
+// Regions on the bottom and right edges may be smaller.
+func (u *Universe) Density(regionHeight, regionWidth uint32) [][]float64 {
+	if regionHeight == 0 || regionWidth == 0 {
+		return nil
+	}
+
+	rows := (u.height + regionHeight - 1) / regionHeight
+	columns := (u.width + regionWidth - 1) / regionWidth
+	live := make([][]float64, rows)
+	size := make([][]float64, rows)
+	for r := range live {
+		live[r] = make([]float64, columns)
+		size[r] = make([]float64, columns)
+	}
+
+	for row := uint32(0); row < u.height; row++ {
+		for column := uint32(0); column < u.width; column++ {
+			r, c := row/regionHeight, column/regionWidth
+			size[r][c]++
+			if u.cells[u.GetIndex(row, column)] != Dead {
+				live[r][c]++
+			}
+		}
+	}
+
+	for r := range live {
+		for c := range live[r] {
+			live[r][c] /= size[r][c]
+		}
+	}
+
+	return live
+}
+
+// Entropy returns the Shannon entropy, in bits, of the configurations of
+// the blocks of blockSize by blockSize cells that tile the universe. With
+// a blockSize of 1 it measures how balanced live and dead cells are, while
+// larger blocks also capture how structured the pattern is. Blocks that do
+// not fit on the bottom and right edges are ignored.
+// See https://en.wikipedia.org/wiki/Entropy_(information_theory)
+func (u *Universe) Entropy(blockSize uint32) float64 {
+	if blockSize == 0 || blockSize > 8 || blockSize > u.height || blockSize > u.width {
+		return 0
+	}
+
+	counts := map[uint64]int{}
+	blocks := 0
+	for row := uint32(0); row+blockSize <= u.height; row += blockSize {
+		for column := uint32(0); column+blockSize <= u.width; column += blockSize {
+			var block uint64
+			for r := uint32(0); r < blockSize; r++ {
+				for c := uint32(0); c < blockSize; c++ {
+					block <<= 1
+					if u.cells[u.GetIndex(row+r, column+c)] != Dead {
+						block |= 1
+					}
+				}
+			}
+			counts[block]++
+			blocks++
+		}
+	}
+
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(blocks)
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpatial(t *testing.T) {
+	t.Run("BoundingBox and Centroid", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		if _, ok := u.BoundingBox(); ok {
+			t.Errorf("Expected no bounding box for a dead universe")
+		}
+		if _, _, ok := u.Centroid(); ok {
+			t.Errorf("Expected no centroid for a dead universe")
+		}
+
+		u.SetRectangle(4, 6, Beehive().Values())
+
+		expected := Rect{Row: 4, Column: 6, Height: 3, Width: 4}
+		if box, _ := u.BoundingBox(); box != expected {
+			t.Errorf("Expected bounding box to be %+v, got %+v", expected, box)
+		}
+		if row, column, _ := u.Centroid(); row != 5 || column != 7.5 {
+			t.Errorf("Expected centroid to be (5, 7.5), got (%f, %f)", row, column)
+		}
+	})
+
+	t.Run("Density", func(t *testing.T) {
+		u := NewUniverse(4, 6)
+		u.SetRectangle(0, 0, [][]uint8{{Alive, Alive}, {Alive, Alive}})
+
+		density := u.Density(2, 4)
+		if len(density) != 2 || len(density[0]) != 2 {
+			t.Fatalf("Expected 2x2 regions, got %v", density)
+		}
+		if density[0][0] != 0.5 || density[0][1] != 0 || density[1][0] != 0 {
+			t.Errorf("Expected density to be 0.5 in the top-left region only, got %v", density)
+		}
+	})
+
+	t.Run("Entropy", func(t *testing.T) {
+		u := NewUniverse(8, 8)
+		if u.Entropy(1) != 0 {
+			t.Errorf("Expected a dead universe to have no entropy, got %f", u.Entropy(1))
+		}
+
+		for row := uint32(0); row < 8; row++ {
+			for column := uint32(0); column < 8; column += 2 {
+				u.ToggleCellAt(row, column)
+			}
+		}
+		if u.Entropy(1) != 1 {
+			t.Errorf("Expected half live cells to have 1 bit of entropy, got %f", u.Entropy(1))
+		}
+		if u.Entropy(2) != 0 {
+			t.Errorf("Expected identical blocks to have no entropy, got %f", u.Entropy(2))
+		}
+
+		u.Randomize(50)
+		if e := u.Entropy(2); e <= 0 || e > 4 || math.IsNaN(e) {
+			t.Errorf("Expected entropy between 0 and 4 bits, got %f", e)
+		}
+	})
+}
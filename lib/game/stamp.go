@@ -0,0 +1,67 @@
+package game
+
+// BlendMode is how the cells of a stamp are combined with the cells of a
+// universe.
+type BlendMode uint8
+
+const (
+	// BlendOverwrite replaces the cells under the stamp, dead ones included.
+	BlendOverwrite BlendMode = iota
+	// BlendOr sets the cells under the live cells of the stamp.
+	BlendOr
+	// BlendXor toggles the cells under the live cells of the stamp.
+	BlendXor
+	// BlendErase kills the cells under the live cells of the stamp.
+	BlendErase
+)
+
+func (m BlendMode) blend(cell, value uint8) uint8 {
+	switch {
+	case m == BlendOverwrite:
+		return value
+	case value == Dead:
+		return cell
+	case m == BlendOr:
+		return value
+	case m == BlendXor && cell == Dead:
+		return value
+	default:
+		return Dead
+	}
+}
+
+// Stamp combines the cells with the universe, with their top-left corner at
+// the given position, which may be negative. Cells beyond the edges wrap
+// around with BoundaryWrap and are clipped otherwise, or always when the
+// universe has neighbors. It returns the number of clipped cells.
+func (u *Universe) Stamp(row, column int, values [][]uint8, mode BlendMode) (clipped int, err error) {
+	if mode > BlendErase {
+		return 0, errInvalidBlendMode
+	}
+
+	height, width := int(u.height), int(u.width)
+	wrap := u.Boundary == BoundaryWrap && u.outside == nil
+	for i, cells := range values {
+		for j, value := range cells {
+			r, c := row+i, column+j
+			if wrap {
+				r, c = (r%height+height)%height, (c%width+width)%width
+			} else if r < 0 || r >= height || c < 0 || c >= width {
+				clipped++
+				continue
+			}
+
+			idx := r*width + c
+			u.cells[idx] = mode.blend(u.cells[idx], value)
+		}
+	}
+	u.changed()
+
+	return clipped, nil
+}
+
+// StampFigure stamps the figure with its anchor at the given position, see
+// Stamp.
+func (u *Universe) StampFigure(row, column int, f *Figure, mode BlendMode) (clipped int, err error) {
+	return u.Stamp(row-f.deltaX, column-f.deltaY, f.values, mode)
+}
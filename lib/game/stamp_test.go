@@ -0,0 +1,72 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestStamp(t *testing.T) {
+	t.Run("Blend modes", func(t *testing.T) {
+		stamp := [][]uint8{{Alive, Alive, Dead, Dead}}
+		for mode, expected := range map[BlendMode]string{
+			BlendOverwrite: "OO..\n",
+			BlendOr:        "OOO.\n",
+			BlendXor:       ".OO.\n",
+			BlendErase:     "..O.\n",
+		} {
+			u := NewUniverse(1, 4)
+			u.Parse("O.O.")
+			if _, err := u.Stamp(0, 0, stamp, mode); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if u.String() != expected {
+				t.Errorf("Expected blend mode %d to give %q, got %q", mode, expected, u.String())
+			}
+		}
+	})
+
+	t.Run("Clip", func(t *testing.T) {
+		u := NewUniverse(4, 4)
+		clipped, err := u.StampFigure(0, 0, Glider(), BlendOr)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := ".O..\nOO..\n....\n....\n"
+		if u.String() != expected || clipped != 5 {
+			t.Errorf("Expected 5 clipped cells and:\n%s\ngot %d and:\n%s", expected, clipped, u)
+		}
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		u := NewUniverse(4, 4)
+		u.Boundary = BoundaryWrap
+		clipped, _ := u.StampFigure(0, 0, Glider(), BlendOr)
+
+		expected := ".O..\nOO.O\n....\nO...\n"
+		if u.String() != expected || clipped != 0 {
+			t.Errorf("Expected no clipped cells and:\n%s\ngot %d and:\n%s", expected, clipped, u)
+		}
+	})
+
+	t.Run("Neighbors", func(t *testing.T) {
+		p := NewParallelUniverse(4, 4)
+		p.Boundary = BoundaryWrap
+		if clipped, _ := p.Stamp(-1, -1, [][]uint8{{Alive, Alive}, {Alive, Alive}}, BlendOr); clipped != 3 {
+			t.Errorf("Expected universes with neighbors to clip, got %d clipped cells", clipped)
+		}
+	})
+
+	t.Run("SetRectangle", func(t *testing.T) {
+		u := NewUniverse(4, 4)
+		u.SetRectangle(3, 3, [][]uint8{{Alive, Alive}, {Alive, Alive}})
+		if u.Population() != 1 {
+			t.Errorf("Expected the rectangle to be clipped, got:\n%s", u)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := NewUniverse(4, 4).Stamp(0, 0, nil, BlendErase+1); err != errInvalidBlendMode {
+			t.Errorf("Expected error to be %v, got %v", errInvalidBlendMode, err)
+		}
+	})
+}
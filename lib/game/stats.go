@@ -0,0 +1,125 @@
+package game
+
+// Stats holds the cell counts of a universe at a given generation.
+type Stats struct {
+	Generation uint32
+	// Population is the number of cells that are not dead.
+	Population uint32
+	// Births is the number of cells that came to life in the last Tick.
+	Births uint32
+	// Deaths is the number of cells that died in the last Tick.
+	Deaths uint32
+}
+
+// tally accounts for a cell going from the old state to the given one.
+func (s *Stats) tally(old, cell uint8) {
+	switch {
+	case cell != Dead:
+		s.Population++
+		if old == Dead {
+			s.Births++
+		}
+	case old != Dead:
+		s.Deaths++
+	}
+}
+
+// Stats returns the counts of the current generation. Births and Deaths
+// refer to the last Tick, while Population is kept up to date with edits.
+func (u *Universe) Stats() Stats {
+	return u.stats
+}
+
+// Population returns the number of cells that are not dead.
+func (u *Universe) Population() uint32 {
+	return u.stats.Population
+}
+
+// KeepStats makes Tick record the Stats of the last size generations, which
+// are available from StatsHistory. A size of 0 disables the history.
+func (u *Universe) KeepStats(size int) {
+	if size <= 0 {
+		u.history = nil
+		return
+	}
+
+	u.history = &StatsHistory{stats: make([]Stats, size)}
+	u.history.add(u.stats)
+}
+
+// StatsHistory returns the history enabled by KeepStats, or nil.
+func (u *Universe) StatsHistory() *StatsHistory {
+	return u.history
+}
+
+// StatsHistory is a bounded buffer of Stats, which drops the oldest
+// generations once it is full.
+type StatsHistory struct {
+	stats  []Stats
+	start  int
+	length int
+}
+
+func (h *StatsHistory) add(s Stats) {
+	if h.length < len(h.stats) {
+		h.stats[(h.start+h.length)%len(h.stats)] = s
+		h.length++
+		return
+	}
+
+	h.stats[h.start] = s
+	h.start = (h.start + 1) % len(h.stats)
+}
+
+// Len returns the number of generations in the history.
+func (h *StatsHistory) Len() int {
+	return h.length
+}
+
+// At returns the Stats of the i-th generation in the history, starting
+// from the oldest one.
+func (h *StatsHistory) At(i int) Stats {
+	return h.stats[(h.start+i)%len(h.stats)]
+}
+
+// Min returns the smallest population in the history.
+func (h *StatsHistory) Min() uint32 {
+	if h.length == 0 {
+		return 0
+	}
+
+	min := h.At(0).Population
+	for i := 1; i < h.length; i++ {
+		if p := h.At(i).Population; p < min {
+			min = p
+		}
+	}
+
+	return min
+}
+
+// Max returns the largest population in the history.
+func (h *StatsHistory) Max() uint32 {
+	var max uint32
+	for i := 0; i < h.length; i++ {
+		if p := h.At(i).Population; p > max {
+			max = p
+		}
+	}
+
+	return max
+}
+
+// Mean returns the average population in the history.
+func (h *StatsHistory) Mean() float64 {
+	if h.length == 0 {
+		return 0
+	}
+
+	var sum float64
+	for i := 0; i < h.length; i++ {
+		sum += float64(h.At(i).Population)
+	}
+
+	return sum / float64(h.length)
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("Tick", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.SetRectangle(5, 5, [][]uint8{{Alive, Alive, Alive}})
+
+		if u.Population() != 3 {
+			t.Errorf("Expected population to be 3, got %d", u.Population())
+		}
+
+		u.Tick()
+
+		expected := Stats{Generation: 1, Population: 3, Births: 2, Deaths: 2}
+		if u.Stats() != expected {
+			t.Errorf("Expected stats to be %+v, got %+v", expected, u.Stats())
+		}
+	})
+
+	t.Run("Edits", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.Randomize(50)
+
+		var expected uint32
+		for i := 0; i < u.Size(); i++ {
+			if u.Cell(uint32(i)) != Dead {
+				expected++
+			}
+		}
+		if u.Population() != expected {
+			t.Errorf("Expected population to be %d, got %d", expected, u.Population())
+		}
+
+		u.ToggleCellAt(0, 0)
+		if u.Population() == expected {
+			t.Errorf("Expected population to change after toggling a cell")
+		}
+
+		u.Reset()
+		if u.Population() != 0 || !u.Dead() {
+			t.Errorf("Expected population to be 0, got %d", u.Population())
+		}
+	})
+
+	t.Run("History", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.SetRectangle(5, 5, Glider().Values())
+		u.KeepStats(3)
+
+		for i := 0; i < 4; i++ {
+			u.Tick()
+		}
+
+		h := u.StatsHistory()
+		if h.Len() != 3 {
+			t.Fatalf("Expected history length to be 3, got %d", h.Len())
+		}
+		for i := 0; i < h.Len(); i++ {
+			if h.At(i).Generation != uint32(i+2) {
+				t.Errorf("Expected entry %d to be generation %d, got %d", i, i+2, h.At(i).Generation)
+			}
+		}
+		if h.Min() != 5 || h.Max() != 5 || h.Mean() != 5 {
+			t.Errorf("Expected min, max and mean to be 5, got %d, %d, %f", h.Min(), h.Max(), h.Mean())
+		}
+	})
+
+	t.Run("History min, max and mean", func(t *testing.T) {
+		h := &StatsHistory{stats: make([]Stats, 4)}
+		for _, p := range []uint32{7, 1, 4, 2, 8} {
+			h.add(Stats{Population: p})
+		}
+
+		if h.Min() != 1 || h.Max() != 8 || h.Mean() != 3.75 {
+			t.Errorf("Expected 1, 8 and 3.75, got %d, %d, %f", h.Min(), h.Max(), h.Mean())
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package game
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// SVG draws the cells of the universe as a vector image, with the same
+// layout and colors as Image.
+func (u *Universe) SVG(opts RenderOptions) string {
+	return opts.svg([][][]uint8{u.rows()}, int(u.height), int(u.width), 1)
+}
+
+// SVG draws the cells of the figure as a vector image, with the same layout
+// and colors as Image.
+func (f *Figure) SVG(opts RenderOptions) string {
+	return opts.svg([][][]uint8{f.values}, int(f.Height()), int(f.Width()), 1)
+}
+
+// SVGFilmstrip runs the universe for the given number of generations and
+// draws them side by side as a vector image, starting with the current one,
+// wrapping to a new row of frames every columns frames if columns is
+// positive. Frames are one cell apart. A negative number of generations
+// draws only the current one.
+func (u *Universe) SVGFilmstrip(generations, columns int, opts RenderOptions) string {
+	generations = max(generations, 0)
+	frames := make([][][]uint8, 0, generations+1)
+	for i := 0; i <= generations; i++ {
+		if i > 0 {
+			u.Tick()
+		}
+		frames = append(frames, u.rows())
+	}
+
+	if columns <= 0 {
+		columns = len(frames)
+	}
+	return opts.svg(frames, int(u.height), int(u.width), columns)
+}
+
+// svg draws frames of the same size in a grid of the given columns.
+func (opts RenderOptions) svg(frames [][][]uint8, height, width, columns int) string {
+	cellSize := max(opts.CellSize, 1)
+	border := 0
+	if opts.Grid {
+		border = 1
+	}
+
+	frameWidth := width*(cellSize+border) + border
+	frameHeight := height*(cellSize+border) + border
+	rows := (len(frames) + columns - 1) / columns
+	columns = min(columns, len(frames))
+	totalWidth := columns*(frameWidth+cellSize) - cellSize
+	totalHeight := rows*(frameHeight+cellSize) - cellSize
+
+	builder := strings.Builder{}
+	builder.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="` + strconv.Itoa(totalWidth) +
+		`" height="` + strconv.Itoa(totalHeight) + `" viewBox="0 0 ` + strconv.Itoa(totalWidth) + ` ` +
+		strconv.Itoa(totalHeight) + `" shape-rendering="crispEdges">` + "\n")
+
+	palette := opts.palette()
+	for i, values := range frames {
+		x := (i % columns) * (frameWidth + cellSize)
+		y := (i / columns) * (frameHeight + cellSize)
+		builder.WriteString(`<g transform="translate(` + strconv.Itoa(x) + ` ` + strconv.Itoa(y) + `)">` + "\n")
+		writeSVGRect(&builder, 0, 0, frameWidth, frameHeight, palette[Dead])
+		opts.writeSVGCells(&builder, values, width, palette)
+		if opts.Grid {
+			writeSVGGrid(&builder, height, width, cellSize, palette[len(palette)-1])
+		}
+		builder.WriteString("</g>\n")
+	}
+	builder.WriteString("</svg>\n")
+
+	return builder.String()
+}
+
+// writeSVGCells draws one rectangle per run of live cells with the same
+// state in a row, grouped by state. Runs cover the gridlines between their
+// cells, which are drawn over them.
+func (opts RenderOptions) writeSVGCells(builder *strings.Builder, values [][]uint8, width int, palette color.Palette) {
+	cellSize := max(opts.CellSize, 1)
+	border := 0
+	if opts.Grid {
+		border = 1
+	}
+
+	states := len(palette) - 1
+	runs := make([][][3]int, states)
+	for r, row := range values {
+		for start := 0; start < width; {
+			state := stateAt(row, start, states)
+			end := start + 1
+			for end < width && stateAt(row, end, states) == state {
+				end++
+			}
+			runs[state] = append(runs[state], [3]int{r, start, end})
+			start = end
+		}
+	}
+
+	for state, stateRuns := range runs {
+		if len(stateRuns) == 0 || state == Dead {
+			continue
+		}
+
+		builder.WriteString(`<g fill="` + svgColor(palette[state]) + `">` + "\n")
+		for _, run := range stateRuns {
+			r, start, end := run[0], run[1], run[2]
+			builder.WriteString(`<rect x="` + strconv.Itoa(border+start*(cellSize+border)) +
+				`" y="` + strconv.Itoa(border+r*(cellSize+border)) +
+				`" width="` + strconv.Itoa((end-start)*(cellSize+border)-border) +
+				`" height="` + strconv.Itoa(cellSize) + `"/>` + "\n")
+		}
+		builder.WriteString("</g>\n")
+	}
+}
+
+// stateAt returns the state of a cell, capped to the last color of the
+// palette like in Image.
+func stateAt(row []uint8, column, states int) int {
+	if column >= len(row) {
+		return Dead
+	}
+
+	return min(int(row[column]), states-1)
+}
+
+// writeSVGGrid draws all the gridlines of a frame as a single path, in the
+// middle of the pixels they take in Image.
+func writeSVGGrid(builder *strings.Builder, height, width, cellSize int, stroke color.Color) {
+	frameWidth := strconv.Itoa(width*(cellSize+1) + 1)
+	frameHeight := strconv.Itoa(height*(cellSize+1) + 1)
+
+	builder.WriteString(`<path stroke="` + svgColor(stroke) + `" stroke-width="1" d="`)
+	for c := 0; c <= width; c++ {
+		builder.WriteString("M" + strconv.Itoa(c*(cellSize+1)) + ".5 0V" + frameHeight)
+	}
+	for r := 0; r <= height; r++ {
+		builder.WriteString("M0 " + strconv.Itoa(r*(cellSize+1)) + ".5H" + frameWidth)
+	}
+	builder.WriteString(`"/>` + "\n")
+}
+
+func writeSVGRect(builder *strings.Builder, x, y, width, height int, fill color.Color) {
+	builder.WriteString(`<rect x="` + strconv.Itoa(x) + `" y="` + strconv.Itoa(y) +
+		`" width="` + strconv.Itoa(width) + `" height="` + strconv.Itoa(height) +
+		`" fill="` + svgColor(fill) + `"/>` + "\n")
+}
+
+// svgColor returns the color in "#rrggbb" notation, or "none" when it is
+// fully transparent.
+func svgColor(c color.Color) string {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return "none"
+	}
+
+	// Undo the alpha premultiplication.
+	hex := "#"
+	for _, channel := range []uint32{r, g, b} {
+		value := strconv.FormatUint(uint64(channel*0xff/a), 16)
+		if len(value) < 2 {
+			value = "0" + value
+		}
+		hex += value
+	}
+
+	return hex
+}
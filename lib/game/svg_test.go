@@ -0,0 +1,74 @@
+package game
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestSVG(t *testing.T) {
+	t.Run("Runs", func(t *testing.T) {
+		f := NewFigure([][]uint8{{Alive, Alive, Alive, Dead, Alive}})
+		svg := f.SVG(RenderOptions{CellSize: 10})
+
+		if !strings.Contains(svg, `width="50" height="10"`) {
+			t.Errorf("Expected a 50x10 image, got:\n%s", svg)
+		}
+		if !strings.Contains(svg, `<rect x="0" y="0" width="30" height="10"/>`) ||
+			!strings.Contains(svg, `<rect x="40" y="0" width="10" height="10"/>`) {
+			t.Errorf("Expected runs of live cells to be merged, got:\n%s", svg)
+		}
+		if strings.Count(svg, "<rect") != 3 {
+			t.Errorf("Expected a background and two runs, got:\n%s", svg)
+		}
+	})
+
+	t.Run("Grid", func(t *testing.T) {
+		svg := Glider().SVG(RenderOptions{CellSize: 4, Grid: true})
+		if !strings.Contains(svg, `width="16" height="16"`) || !strings.Contains(svg, `<path stroke="#cccccc"`) {
+			t.Errorf("Expected a 16x16 image with gridlines, got:\n%s", svg)
+		}
+		if !strings.Contains(svg, `<rect x="1" y="11" width="14" height="4"/>`) {
+			t.Errorf("Expected the bottom row of the glider to span the gridlines, got:\n%s", svg)
+		}
+		if !strings.Contains(svg, `M5.5 0V16`) || !strings.Contains(svg, `M0 15.5H16`) {
+			t.Errorf("Expected gridlines between and around the cells, got:\n%s", svg)
+		}
+	})
+
+	t.Run("Valid XML", func(t *testing.T) {
+		u := NewUniverse(16, 16)
+		u.Randomize(40)
+
+		var doc struct{}
+		if err := xml.Unmarshal([]byte(u.SVG(RenderOptions{})), &doc); err != nil {
+			t.Errorf("Expected valid XML, got %v", err)
+		}
+	})
+}
+
+func TestSVGFilmstrip(t *testing.T) {
+	u := NewUniverse(8, 8)
+	u.SetRectangle(3, 2, [][]uint8{{Alive, Alive, Alive}})
+
+	svg := u.SVGFilmstrip(4, 3, RenderOptions{CellSize: 2})
+	if strings.Count(svg, "<g transform") != 5 {
+		t.Errorf("Expected 5 frames, got:\n%s", svg)
+	}
+	if !strings.Contains(svg, `width="52" height="34"`) {
+		t.Errorf("Expected two rows of three frames, got:\n%s", svg)
+	}
+	if !strings.Contains(svg, `translate(18 18)`) {
+		t.Errorf("Expected the fifth frame in the second row, got:\n%s", svg)
+	}
+	if u.Generation != 4 {
+		t.Errorf("Expected the universe to be at generation 4, got %d", u.Generation)
+	}
+
+	for _, generations := range []int{-1, -2} {
+		svg := u.SVGFilmstrip(generations, 3, RenderOptions{CellSize: 2})
+		if strings.Count(svg, "<g transform") != 1 || u.Generation != 4 {
+			t.Errorf("Expected only the current frame for %d generations, got generation %d:\n%s", generations, u.Generation, svg)
+		}
+	}
+}
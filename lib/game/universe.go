@@ -9,6 +9,26 @@ const (
 	Alive
 )
 
+// maxDecodedCells is the largest number of cells of a universe or pattern
+// decoded from JSON, RLE or a snapshot, 4096 by 4096, so that untrusted
+// input cannot allocate too much. maxDecodedRows is the largest number of
+// rows of the ones decoded from RLE, which takes memory for every row.
+const (
+	maxDecodedCells = 1 << 24
+	maxDecodedRows  = 1 << 16
+)
+
+// Boundary describes how a compiled rule treats the cells beyond the edges
+// of a universe.
+type Boundary uint8
+
+const (
+	// BoundaryDead considers every cell outside of the grid to be dead.
+	BoundaryDead Boundary = iota
+	// BoundaryWrap joins opposite edges, so the grid behaves like a torus.
+	BoundaryWrap
+)
+
 type Universe struct {
 	height     uint32
 	width      uint32
@@ -16,8 +36,21 @@ type Universe struct {
 	newCells   []uint8
 	stable     bool
 	Generation uint32
+	Boundary   Boundary
 
+	// Rules computes the next state of a single cell. It is only used
+	// when no compiled rule has been set with SetRule.
 	Rules func(cell uint8, row, column uint32) uint8 `json:"-"`
+
+	stats    Stats
+	history  *StatsHistory
+	activity *Activity
+
+	rule   *Rule
+	cycles *cycleDetector
+	// outside returns the state of a cell beyond the edges of the grid,
+	// replacing the Boundary for universes that have neighbors.
+	outside func(row, column int32) uint8
 }
 
 func NewUniverse(height, width uint32) *Universe {
@@ -34,6 +67,16 @@ func NewUniverse(height, width uint32) *Universe {
 	return u
 }
 
+// allocate replaces the cells with dead cells of the given size.
+func (u *Universe) allocate(height, width uint32) {
+	u.height, u.width = height, width
+	u.cells = make([]uint8, height*width)
+	u.newCells = make([]uint8, height*width)
+	if u.activity != nil {
+		u.activity = newActivity(len(u.cells))
+	}
+}
+
 func (u *Universe) Height() uint32 {
 	return u.height
 }
@@ -47,13 +90,7 @@ func (u *Universe) Size() int {
 }
 
 func (u *Universe) Dead() bool {
-	for i := range u.cells {
-		if u.cells[i] == Alive {
-			return false
-		}
-	}
-
-	return true
+	return u.stats.Population == 0
 }
 
 // Stable returns true if the universe has reached a stable state.
@@ -71,7 +108,53 @@ func (u *Universe) GetIndex(row, column uint32) uint32 {
 	return row*u.width + column
 }
 
+// SetRule makes the universe evaluate the given compiled rule, which is
+// much faster than calling Rules for every cell. Rules is also replaced with
+// an equivalent per-cell function, so both stay consistent. Setting a nil
+// rule goes back to calling Rules on every Tick.
+func (u *Universe) SetRule(r *Rule) {
+	u.rule = r
+	if r == nil {
+		return
+	}
+
+	u.Rules = func(cell uint8, row, column uint32) uint8 {
+		return r.next(u.neighborhood(r, int32(row), int32(column)))
+	}
+}
+
+// Rule returns the compiled rule set with SetRule, or nil if the universe
+// uses its Rules function.
+func (u *Universe) Rule() *Rule {
+	return u.rule
+}
+
 func (u *Universe) Tick() {
+	var stats Stats
+	if u.rule != nil {
+		u.stable = u.tickRule(&stats)
+	} else {
+		u.stable = u.tickRules(&stats)
+	}
+
+	u.Generation++
+	if u.activity != nil {
+		u.activity.add(u.cells, u.newCells)
+	}
+	copy(u.cells, u.newCells)
+
+	stats.Generation = u.Generation
+	u.stats = stats
+	if u.history != nil {
+		u.history.add(stats)
+	}
+
+	if u.cycles != nil {
+		u.cycles.observe(u.Generation, u.Hash())
+	}
+}
+
+func (u *Universe) tickRules(stats *Stats) bool {
 	stable := true
 	for row := uint32(0); row < u.height; row++ {
 		for column := uint32(0); column < u.width; column++ {
@@ -82,29 +165,106 @@ func (u *Universe) Tick() {
 			if u.newCells[cellIndex] != cell {
 				stable = false
 			}
+			stats.tally(cell, u.newCells[cellIndex])
 		}
 	}
 
-	u.stable = stable
-	u.Generation++
-	copy(u.cells, u.newCells)
+	return stable
 }
 
-func (u *Universe) Reset() {
-	for i := range u.cells {
-		u.cells[i] = Dead
+// tickRule evaluates the compiled rule, sliding the neighborhood index along
+// each row so that only the incoming column has to be read for every cell.
+func (u *Universe) tickRule(stats *Stats) bool {
+	stable := true
+	table, elementary := u.rule.table, u.rule.elementary
+	height, width := int32(u.height), int32(u.width)
+	for row := int32(0); row < height; row++ {
+		var idx uint16
+		if elementary {
+			idx = u.live(row, -1)<<1 | u.live(row, 0)
+		} else {
+			idx = u.column(row, -1)<<1 | u.column(row, 0)<<2
+		}
+
+		cellIndex := row * width
+		for column := int32(0); column < width; column++ {
+			if elementary {
+				idx = (idx<<1 | u.live(row, column+1)) & (elementaryTableSize - 1)
+			} else {
+				idx = (idx>>1)&columnMask | u.column(row, column+1)<<2
+			}
+
+			cell := table[idx]
+			u.newCells[cellIndex] = cell
+			if cell != u.cells[cellIndex] {
+				stable = false
+			}
+			stats.tally(u.cells[cellIndex], cell)
+			cellIndex++
+		}
 	}
-	u.Generation = 0
+
+	return stable
+}
+
+// neighborhood returns the lookup table index of a cell for the given rule.
+func (u *Universe) neighborhood(r *Rule, row, column int32) uint16 {
+	if r.elementary {
+		return u.live(row, column-1)<<2 | u.live(row, column)<<1 | u.live(row, column+1)
+	}
+
+	return u.column(row, column-1) | u.column(row, column)<<1 | u.column(row, column+1)<<2
+}
+
+// column returns the three cells of a column centered on row, placed at the
+// bits of the leftmost column of a neighborhood index.
+func (u *Universe) column(row, column int32) uint16 {
+	if row > 0 && row < int32(u.height)-1 && column >= 0 && column < int32(u.width) {
+		idx := uint32(row)*u.width + uint32(column)
+		return liveBit(u.cells[idx-u.width]) | liveBit(u.cells[idx])<<3 | liveBit(u.cells[idx+u.width])<<6
+	}
+
+	return u.live(row-1, column) | u.live(row, column)<<3 | u.live(row+1, column)<<6
+}
+
+// live returns 1 if the cell at the given position is not dead, resolving
+// positions outside of the grid according to the Boundary.
+func (u *Universe) live(row, column int32) uint16 {
+	return liveBit(u.cellAt(row, column))
+}
+
+func (u *Universe) cellAt(row, column int32) uint8 {
+	height, width := int32(u.height), int32(u.width)
+	if row >= 0 && row < height && column >= 0 && column < width {
+		return u.cells[row*width+column]
+	}
+
+	switch {
+	case u.outside != nil:
+		return u.outside(row, column)
+	case u.Boundary == BoundaryWrap:
+		row, column = (row%height+height)%height, (column%width+width)%width
+		return u.cells[row*width+column]
+	default:
+		return Dead
+	}
+}
+
+func liveBit(cell uint8) uint16 {
+	if cell != Dead {
+		return 1
+	}
+
+	return 0
 }
 
-func (u *Universe) Randomize(livePopulation int) {
+func (u *Universe) Reset() {
 	for i := range u.cells {
-		if randomNumber() < livePopulation {
-			u.cells[i] = Alive
-		} else {
-			u.cells[i] = Dead
-		}
+		u.cells[i] = Dead
 	}
+	u.Generation = 0
+	u.stats = Stats{}
+	u.changed()
 }
 
 func (u *Universe) ToggleCellAt(row, column uint32) {
@@ -114,15 +274,13 @@ func (u *Universe) ToggleCellAt(row, column uint32) {
 	} else {
 		u.cells[idx] = Alive
 	}
+	u.changed()
 }
 
+// SetRectangle overwrites the cells with their top-left corner at the given
+// position. Cells beyond the edges are handled like in Stamp.
 func (u *Universe) SetRectangle(startingRow, startingColumn uint32, values [][]uint8) {
-	for i, row := range values {
-		for j, value := range row {
-			idx := u.GetIndex(startingRow+uint32(i), startingColumn+uint32(j))
-			u.cells[idx] = value
-		}
-	}
+	u.Stamp(int(startingRow), int(startingColumn), values, BlendOverwrite)
 }
 
 func (u *Universe) Read(p []byte) (n int, err error) {
@@ -140,6 +298,7 @@ func (u *Universe) Write(p []byte) (n int, err error) {
 	}
 
 	copy(u.cells, p)
+	u.changed()
 	return len(p), nil
 }
 
@@ -185,5 +344,22 @@ func (u *Universe) Parse(data string) error {
 		}
 	}
 
+	u.changed()
 	return nil
 }
+
+// changed must be called after the cells have been edited outside of Tick.
+func (u *Universe) changed() {
+	u.stats.Generation = u.Generation
+	u.stats.Population = 0
+	for _, cell := range u.cells {
+		if cell != Dead {
+			u.stats.Population++
+		}
+	}
+
+	if u.cycles != nil {
+		u.cycles.reset()
+		u.cycles.observe(u.Generation, u.Hash())
+	}
+}
@@ -108,11 +108,8 @@ func TestUniverse(t *testing.T) {
 			t.Errorf("Expected universe to be alive, got dead")
 		}
 
-		var i uint32
-		for i = 0; i < uint32(u.Size()); i++ {
-			if u.Cell(i) != u2.Cell(i) {
-				t.Errorf("Expected cell %d in u2 to be %d, got %d", i, u.Cell(uint32(i)), u2.Cell(uint32(i)))
-			}
+		if patch, err := u2.Diff(u); err != nil || !patch.Empty() {
+			t.Errorf("Expected u2 to match u, got %v and differences\n%s", err, patch)
 		}
 	})
 
@@ -140,11 +137,8 @@ func TestUniverse(t *testing.T) {
 			t.Errorf("Expected error to be nil, got %v", err)
 		}
 
-		var i uint32
-		for i = 0; i < uint32(u.Size()); i++ {
-			if u.Cell(i) != u2.Cell(i) {
-				t.Errorf("Expected cell %d in u2 to be %d, got %d", i, u.Cell(uint32(i)), u2.Cell(uint32(i)))
-			}
+		if patch, err := u2.Diff(u); err != nil || !patch.Empty() {
+			t.Errorf("Expected u2 to match u, got %v and differences\n%s", err, patch)
 		}
 	})
 
@@ -9,32 +9,61 @@ import (
 )
 
 const (
-	toggleAction = iota
-	gliderAction = iota
-	pulsarAction = iota
+	toggleAction  = iota
+	gliderAction  = iota
+	pulsarAction  = iota
+	patternAction = iota
+	extractAction = iota
 )
 
 const (
 	cellSize   = 10
 	borderSize = 1
+
+	sparklineWidth  = 120
+	sparklineHeight = 24
+
+	// maxBoardSize is the largest height and width of the board, as in the
+	// board-size controls.
+	maxBoardSize = 512
+
+	// autoPausePeriod is the longest cycle that pauses the simulation when
+	// auto-pause is enabled.
+	autoPausePeriod = 30
 )
 
 var (
 	universe       *game.Universe
+	history        *game.History
+	runner         = game.NewRunner(0)
+	run            *game.Run
 	ctx            js.Value
+	sparklineCtx   js.Value
+	population     js.Value
+	timeline       js.Value
+	generation     js.Value
+	furthest       uint32
 	lastTick       float64
 	animationID           = -1
 	clickAction           = toggleAction
 	width, height  uint32 = 64, 64
 	livePopulation        = 50
 	renderingSpeed        = 50
+	showHeatmap           = false
+	pattern               = mustLoadPattern("gosper-glider-gun")
+	rotation              = 0
+	flipped               = false
 )
 
 func main() {
 	done := make(chan bool)
 
-	universe = game.NewUniverse(width, height)
+	universe = game.NewUniverse(height, width)
+	universe.SetRule(mustLookupRule("conway"))
 	universe.Randomize(livePopulation)
+	universe.KeepStats(sparklineWidth)
+	history = game.NewHistory(universe, 0)
+	run = runner.Start(history)
 
 	window := js.Global()
 	document := window.Get("document")
@@ -42,16 +71,28 @@ func main() {
 	canvas := setupCanvas()
 	ctx = canvas.Call("getContext", "2d")
 
+	sparkline := document.Call("getElementById", "sparkline")
+	sparkline.Set("width", sparklineWidth)
+	sparkline.Set("height", sparklineHeight)
+	sparklineCtx = sparkline.Call("getContext", "2d")
+	population = document.Call("getElementById", "population")
+	timeline = document.Call("getElementById", "timeline")
+	generation = document.Call("getElementById", "generation")
+	extracted := document.Call("getElementById", "extracted")
+	setupPatternSelect()
+
 	gps := document.Call("getElementById", "gps")
 	ticks := float64(0)
 	renderingLoops := 0
 
+	playPauseButton := document.Call("getElementById", "play-pause")
+
 	// Rendering loop
 	var draw js.Func
 	draw = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		renderingLoops = renderingLoops + 1
 		if renderingLoops > (5 - renderingSpeed) {
-			universe.Tick()
+			run.Next()
 			renderingLoops = 0
 
 			ticks = ticks + 1
@@ -68,6 +109,11 @@ func main() {
 		}
 
 		drawCanvas()
+		if run.Done() {
+			playPauseButton.Set("textContent", "Play")
+			animationID = -1
+			return nil
+		}
 		animationID = window.Call("requestAnimationFrame", draw).Int()
 		return nil
 	})
@@ -78,7 +124,8 @@ func main() {
 	addEventListener("live-population", "change", func(this js.Value, args []js.Value) interface{} {
 		newValue := args[0].Get("target").Get("value").String()
 		livePopulation, _ = strconv.Atoi(newValue)
-		universe.Randomize(livePopulation)
+		history.Randomize(livePopulation)
+		drawCanvas()
 		return nil
 	})
 
@@ -89,24 +136,37 @@ func main() {
 		return nil
 	})
 
+	addEventListener("heatmap", "change", func(this js.Value, args []js.Value) interface{} {
+		showHeatmap = args[0].Get("target").Get("checked").Bool()
+		universe.TrackActivity(showHeatmap)
+		drawCanvas()
+		return nil
+	})
+
 	addEventListener("canvas", "click", func(this js.Value, args []js.Value) interface{} {
 		boundingRect := canvas.Call("getBoundingClientRect")
 		widthScale := canvas.Get("width").Int() / boundingRect.Get("width").Int()
 		heightScale := canvas.Get("height").Int() / boundingRect.Get("height").Int()
 		canvasX := (args[0].Get("clientX").Int() - boundingRect.Get("left").Int()) * widthScale
 		canvasY := (args[0].Get("clientY").Int() - boundingRect.Get("top").Int()) * heightScale
-		row := uint32(math.Floor(float64(canvasY) / (cellSize + borderSize)))
-		col := uint32(math.Floor(float64(canvasX) / (cellSize + borderSize)))
+		row := int(math.Floor(float64(canvasY) / (cellSize + borderSize)))
+		col := int(math.Floor(float64(canvasX) / (cellSize + borderSize)))
 
 		switch clickAction {
 		case gliderAction:
-			figure := game.Glider()
-			universe.SetRectangle(row-figure.DeltaX(), col-figure.DeltaY(), figure.Values())
+			stamp(row, col, orient(game.Glider()))
 		case pulsarAction:
-			figure := game.Pulsar()
-			universe.SetRectangle(row-figure.DeltaX(), col-figure.DeltaY(), figure.Values())
+			stamp(row, col, orient(game.Pulsar()))
+		case patternAction:
+			stamp(row, col, orient(pattern.Figure))
+		case extractAction:
+			if component, ok := universe.ComponentAt(row, col); ok {
+				extracted.Set("value", component.Figure.RLE())
+			}
 		default:
-			universe.ToggleCellAt(row, col)
+			history.Edit(func(u *game.Universe) {
+				u.Stamp(row, col, [][]uint8{{game.Alive}}, game.BlendXor)
+			})
 		}
 
 		drawCanvas()
@@ -128,22 +188,51 @@ func main() {
 		return nil
 	})
 
+	addEventListener("pattern", "click", func(this js.Value, args []js.Value) interface{} {
+		clickAction = patternAction
+		return nil
+	})
+
+	addEventListener("extract", "click", func(this js.Value, args []js.Value) interface{} {
+		clickAction = extractAction
+		return nil
+	})
+
+	addEventListener("pattern-name", "change", func(this js.Value, args []js.Value) interface{} {
+		pattern = mustLoadPattern(args[0].Get("target").Get("value").String())
+		document.Call("getElementById", "pattern").Set("checked", true)
+		clickAction = patternAction
+		return nil
+	})
+
+	orientation := document.Call("getElementById", "orientation")
+	addEventListener("rotate", "click", func(this js.Value, args []js.Value) interface{} {
+		rotation = (rotation + 1) % 4
+		orientation.Set("innerText", orientationText())
+		return nil
+	})
+
+	addEventListener("flip", "click", func(this js.Value, args []js.Value) interface{} {
+		flipped = !flipped
+		orientation.Set("innerText", orientationText())
+		return nil
+	})
+
 	addEventListener("conway", "click", func(this js.Value, args []js.Value) interface{} {
-		universe.Rules = universe.ConwayRules
+		universe.SetRule(mustLookupRule("conway"))
 		return nil
 	})
 
 	addEventListener("seeds", "click", func(this js.Value, args []js.Value) interface{} {
-		universe.Rules = universe.SeedsRules
+		universe.SetRule(mustLookupRule("seeds"))
 		return nil
 	})
 
 	addEventListener("daynight", "click", func(this js.Value, args []js.Value) interface{} {
-		universe.Rules = universe.DayAndNightRules
+		universe.SetRule(mustLookupRule("dayandnight"))
 		return nil
 	})
 
-	playPauseButton := document.Call("getElementById", "play-pause")
 	addEventListener("play-pause", "click", func(this js.Value, args []js.Value) interface{} {
 		if animationID != -1 {
 			playPauseButton.Set("textContent", "Play")
@@ -151,23 +240,98 @@ func main() {
 			animationID = -1
 		} else {
 			playPauseButton.Set("textContent", "Pause")
-			window.Call("requestAnimationFrame", draw)
+			run = runner.Start(history)
+			animationID = window.Call("requestAnimationFrame", draw).Int()
+		}
+		return nil
+	})
+
+	addEventListener("auto-pause", "change", func(this js.Value, args []js.Value) interface{} {
+		runner.Stop = nil
+		if args[0].Get("target").Get("checked").Bool() {
+			runner.Stop = []game.StopCondition{game.StopWhenExtinct(), game.StopWhenPeriodic(autoPausePeriod)}
 		}
+		run = runner.Start(history)
 		return nil
 	})
 
 	addEventListener("reset", "click", func(this js.Value, args []js.Value) interface{} {
-		universe.Reset()
+		history.Reset()
+		furthest = 0
 		drawCanvas()
 		return nil
 	})
 
 	addEventListener("randomize", "click", func(this js.Value, args []js.Value) interface{} {
-		universe.Randomize(livePopulation)
+		history.Randomize(livePopulation)
 		drawCanvas()
 		return nil
 	})
 
+	addEventListener("resize", "click", func(this js.Value, args []js.Value) interface{} {
+		newHeight := document.Call("getElementById", "board-height").Get("valueAsNumber").Float()
+		newWidth := document.Call("getElementById", "board-width").Get("valueAsNumber").Float()
+		// Empty inputs are NaN, which fails both comparisons.
+		if !(newHeight >= 1 && newWidth >= 1) {
+			return nil
+		}
+
+		newHeight, newWidth = min(newHeight, maxBoardSize), min(newWidth, maxBoardSize)
+		history.Edit(func(u *game.Universe) {
+			u.Resize(uint32(newHeight), uint32(newWidth), game.AnchorCenter)
+		})
+		resizeCanvas()
+		return nil
+	})
+
+	addEventListener("crop", "click", func(this js.Value, args []js.Value) interface{} {
+		history.Edit(func(u *game.Universe) {
+			u.Crop()
+		})
+		resizeCanvas()
+		return nil
+	})
+
+	addEventListener("undo", "click", func(this js.Value, args []js.Value) interface{} {
+		history.Undo()
+		drawHistory()
+		return nil
+	})
+
+	addEventListener("redo", "click", func(this js.Value, args []js.Value) interface{} {
+		history.Redo()
+		drawHistory()
+		return nil
+	})
+
+	addEventListener("timeline", "input", func(this js.Value, args []js.Value) interface{} {
+		target, _ := strconv.Atoi(args[0].Get("target").Get("value").String())
+		history.SeekGeneration(uint32(target))
+		drawCanvas()
+		return nil
+	})
+
+	// Ctrl+Z undoes the last edit, Ctrl+Shift+Z and Ctrl+Y redo it.
+	document.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		event := args[0]
+		if !event.Get("ctrlKey").Bool() && !event.Get("metaKey").Bool() {
+			return nil
+		}
+
+		switch key := event.Get("key").String(); {
+		case key == "y" || key == "Z" || (key == "z" && event.Get("shiftKey").Bool()):
+			history.Redo()
+		case key == "z":
+			history.Undo()
+		default:
+			return nil
+		}
+
+		event.Call("preventDefault")
+		drawHistory()
+		return nil
+	}))
+
 	// Start rendering
 	lastTick = window.Get("performance").Call("now").Float()
 	window.Call("requestAnimationFrame", draw)
@@ -185,9 +349,86 @@ func setupCanvas() js.Value {
 	return canvas
 }
 
+// stamp places a figure on the universe, recording it in the history.
+func stamp(row, col int, figure *game.Figure) {
+	history.Edit(func(u *game.Universe) {
+		u.StampFigure(row, col, figure, game.BlendOverwrite)
+	})
+}
+
+// resizeCanvas fits the canvas and the board-size controls to the universe
+// after it changed size.
+func resizeCanvas() {
+	height, width = universe.Height(), universe.Width()
+	document := js.Global().Get("document")
+	document.Call("getElementById", "board-height").Set("value", height)
+	document.Call("getElementById", "board-width").Set("value", width)
+	setupCanvas()
+	furthest = universe.Generation
+	drawCanvas()
+}
+
+// drawHistory redraws the universe after an undo or redo, which may have
+// changed its size.
+func drawHistory() {
+	if universe.Height() != height || universe.Width() != width {
+		resizeCanvas()
+		return
+	}
+	drawCanvas()
+}
+
+// orient applies the rotation and flip chosen in the controls to a figure.
+func orient(figure *game.Figure) *game.Figure {
+	if flipped {
+		figure = figure.FlipHorizontal()
+	}
+
+	return figure.Rotate(rotation)
+}
+
+func orientationText() string {
+	text := strconv.Itoa(rotation*90) + "°"
+	if flipped {
+		text += ", flipped"
+	}
+
+	return text
+}
+
+// setupPatternSelect lists the patterns of the catalog, grouped by category.
+func setupPatternSelect() {
+	document := js.Global().Get("document")
+	selectElement := document.Call("getElementById", "pattern-name")
+	for _, category := range game.Categories() {
+		group := document.Call("createElement", "optgroup")
+		group.Set("label", category)
+		for _, entry := range game.Catalog(category) {
+			option := document.Call("createElement", "option")
+			option.Set("value", entry.Name)
+			option.Set("textContent", entry.Title)
+			option.Set("selected", entry.Name == "gosper-glider-gun")
+			group.Call("appendChild", option)
+		}
+		selectElement.Call("appendChild", group)
+	}
+}
+
 func drawCanvas() {
 	drawGrid()
 	drawCells()
+	drawPopulation()
+	drawTimeline()
+}
+
+// drawTimeline moves the timeline slider to the current generation, and
+// spans it from the earliest generation recorded to the furthest reached.
+func drawTimeline() {
+	furthest = max(furthest, universe.Generation)
+	timeline.Set("min", history.Earliest())
+	timeline.Set("max", furthest)
+	timeline.Set("value", universe.Generation)
+	generation.Set("innerText", universe.Generation)
 }
 
 func drawGrid() {
@@ -248,9 +489,72 @@ func drawCells() {
 		}
 	}
 
+	// Activity heatmap
+	if showHeatmap {
+		heat := universe.Activity().Heat()
+		for row := 0; row < height; row++ {
+			for col := 0; col < width; col++ {
+				idx := universe.GetIndex(uint32(row), uint32(col))
+				if heat[idx] == 0 {
+					continue
+				}
+
+				ctx.Set("fillStyle", "rgba(255, 77, 79, "+strconv.FormatFloat(heat[idx]*0.6, 'f', 2, 64)+")")
+				ctx.Call("fillRect",
+					col*(cellSize+borderSize)+borderSize,
+					row*(cellSize+borderSize)+borderSize,
+					cellSize,
+					cellSize,
+				)
+			}
+		}
+	}
+
 	ctx.Call("stroke")
 }
 
+func drawPopulation() {
+	population.Set("innerText", universe.Population())
+
+	history := universe.StatsHistory()
+	min, max := float64(history.Min()), float64(history.Max())
+	if max == min {
+		max = min + 1
+	}
+
+	sparklineCtx.Call("clearRect", 0, 0, sparklineWidth, sparklineHeight)
+	sparklineCtx.Call("beginPath")
+	sparklineCtx.Set("strokeStyle", "#1890ff")
+	for i := 0; i < history.Len(); i++ {
+		p := float64(history.At(i).Population)
+		y := sparklineHeight - 1 - (p-min)/(max-min)*(sparklineHeight-2)
+		if i == 0 {
+			sparklineCtx.Call("moveTo", i, y)
+		} else {
+			sparklineCtx.Call("lineTo", i, y)
+		}
+	}
+	sparklineCtx.Call("stroke")
+}
+
+func mustLookupRule(name string) *game.Rule {
+	rule, err := game.LookupRule(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return rule
+}
+
+func mustLoadPattern(name string) *game.Pattern {
+	pattern, err := game.LoadPattern(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return pattern
+}
+
 func addEventListener(elementID string, eventName string, callback func(this js.Value, args []js.Value) interface{}) {
 	js.Global().
 		Get("document").